@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+const apiKeysUsage = `usage:
+  api-keys create -name <name> -scopes <scope,...>
+  api-keys rotate [-grace-period d] <api key uuid>
+  api-keys revoke <api key uuid>
+  api-keys list`
+
+// go run ./cmd api-keys create|rotate|revoke|list. A chave em claro só aparece na saída do create e do rotate
+func runAPIKeys(keys port.APIKeyServicePort, args []string) {
+	if len(args) == 0 {
+		usage(apiKeysUsage)
+	}
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("api-keys create", flag.ExitOnError)
+		name := fs.String("name", "", "name of the calling service")
+		scopes := fs.String("scopes", "", "comma separated scopes: customer, teller, admin or account:<number>")
+		fs.Parse(args[1:])
+
+		key, secret, err := keys.CreateAPIKey(ctx, *name, splitScopes(*scopes))
+		if err != nil {
+			fatal("failed to create api key", "err", err)
+		}
+
+		slog.Info("api key created", "api_key_uuid", key.APIKeyUUID, "name", key.Name, "scopes", key.Scopes)
+		fmt.Println(secret)
+	case "rotate":
+		fs := flag.NewFlagSet("api-keys rotate", flag.ExitOnError)
+		gracePeriod := fs.Duration("grace-period", 0, "how long the previous key keeps working")
+		fs.Parse(args[1:])
+
+		key, secret, err := keys.RotateAPIKey(ctx, parseAPIKeyUUID(fs.Args()), *gracePeriod)
+		if err != nil {
+			fatal("failed to rotate api key", "err", err)
+		}
+
+		slog.Info("api key rotated", "api_key_uuid", key.APIKeyUUID, "name", key.Name, "grace_period", *gracePeriod)
+		fmt.Println(secret)
+	case "revoke":
+		if err := keys.RevokeAPIKey(ctx, parseAPIKeyUUID(args[1:])); err != nil {
+			fatal("failed to revoke api key", "err", err)
+		}
+
+		slog.Info("api key revoked")
+	case "list":
+		list, err := keys.ListAPIKeys(ctx)
+		if err != nil {
+			fatal("failed to list api keys", "err", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "UUID\tNAME\tKEY ID\tSCOPES\tCREATED\tLAST USED\tREVOKED")
+		for _, k := range list {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n", k.APIKeyUUID, k.Name, k.KeyID, strings.Join(k.Scopes, ","),
+				k.CreatedAt.Format(time.RFC3339), formatOptionalTime(k.LastUsedAt), formatOptionalTime(k.RevokedAt))
+		}
+		w.Flush()
+	default:
+		usage(apiKeysUsage)
+	}
+}
+
+func splitScopes(s string) []string {
+	var scopes []string
+	for _, scope := range strings.Split(s, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+func parseAPIKeyUUID(args []string) uuid.UUID {
+	if len(args) != 1 {
+		usage(apiKeysUsage)
+	}
+
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		fatal("invalid api key uuid", "api_key_uuid", args[0], "err", err)
+	}
+
+	return id
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+
+	return t.Format(time.RFC3339)
+}
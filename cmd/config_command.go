@@ -0,0 +1,23 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+)
+
+// go run ./cmd [flags] config print [-format yaml|toml]
+func runConfig(cfg *config.Config, args []string) {
+	if len(args) == 0 || args[0] != "print" {
+		usage("usage: config print [-format yaml|toml]")
+	}
+
+	fs := flag.NewFlagSet("config print", flag.ExitOnError)
+	format := fs.String("format", "yaml", "output format: yaml or toml")
+	fs.Parse(args[1:])
+
+	if err := config.Write(os.Stdout, cfg.Redacted(), *format); err != nil {
+		fatal("failed to print configuration", "err", err)
+	}
+}
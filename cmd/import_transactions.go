@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/adapter/ingest"
+	app "github.com/viquitorreis/my-grpc-go-server/internal/application"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+// go run ./cmd import-transactions [-format csv|ndjson] [-dry-run] [-batch-size n] arquivo
+func runImportTransactions(db port.BankDatabasePort, defaultBatchSize int, args []string) {
+	fs := flag.NewFlagSet("import-transactions", flag.ExitOnError)
+	format := fs.String("format", "", "file format: csv or ndjson (default: from the file extension)")
+	dryRun := fs.Bool("dry-run", false, "validate the file without writing transactions")
+	batchSize := fs.Int("batch-size", defaultBatchSize, "transactions written per database batch")
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		usage("usage: import-transactions [-format csv|ndjson] [-dry-run] [-batch-size n] <file>")
+	}
+
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = ingest.FormatFromPath(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		fatal("failed to open transaction file", "err", err)
+	}
+	defer f.Close()
+
+	src, err := ingest.NewTransactionSource(*format, f)
+	if err != nil {
+		fatal("failed to read transaction file", "err", err)
+	}
+
+	importer := app.NewTransactionImportService(db, *batchSize)
+
+	res, err := importer.ImportTransactions(context.Background(), src, *dryRun)
+	if err != nil {
+		fatal("failed to import transactions", "err", err)
+	}
+
+	for _, e := range res.Errors {
+		slog.Warn("row rejected", "err", e.Error())
+	}
+
+	if res.ErrorsTruncated {
+		slog.Warn("only the first row errors were reported", "reported", len(res.Errors))
+	}
+
+	slog.Info("transaction import finished", "rows_read", res.RowsRead, "rows_imported", res.RowsImported,
+		"rows_failed", res.RowsFailed, "dry_run", res.DryRun)
+
+	if res.RowsFailed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+
+	db "github.com/viquitorreis/my-grpc-go-server/db/migrations"
+)
+
+// go run ./cmd reset-database -confirm: desfaz todas as migrations e aplica de novo, apagando os dados
+func runResetDatabase(conn *sql.DB, args []string) {
+	fs := flag.NewFlagSet("reset-database", flag.ExitOnError)
+	confirm := fs.Bool("confirm", false, "confirm that all data will be deleted")
+	fs.Parse(args)
+
+	if !*confirm {
+		usage("usage: reset-database -confirm (drops every table and all of its data)")
+	}
+
+	db.Reset(conn)
+}
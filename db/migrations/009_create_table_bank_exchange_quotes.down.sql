@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bank_exchange_quotes CASCADE;
\ No newline at end of file
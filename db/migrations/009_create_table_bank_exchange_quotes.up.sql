@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS bank_exchange_quotes(
+    quote_uuid                  UUID            PRIMARY KEY,
+    from_currency               VARCHAR(5)      NOT NULL,
+    to_currency                 VARCHAR(5)      NOT NULL,
+    mid_rate                    NUMERIC(20,10)  NOT NULL,
+    spread                      NUMERIC(10,6)   NOT NULL,
+    rate                        NUMERIC(20,10)  NOT NULL,
+    expires_at                  TIMESTAMPTZ     NOT NULL,
+    used_at                     TIMESTAMPTZ,
+    created_at 			            TIMESTAMPTZ,
+    updated_at 			            TIMESTAMPTZ
+);
\ No newline at end of file
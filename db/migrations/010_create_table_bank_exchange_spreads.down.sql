@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bank_exchange_spreads CASCADE;
\ No newline at end of file
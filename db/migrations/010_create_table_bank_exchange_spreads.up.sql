@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS bank_exchange_spreads(
+    spread_uuid                 UUID            PRIMARY KEY,
+    from_currency               VARCHAR(5)      NOT NULL,
+    to_currency                 VARCHAR(5)      NOT NULL,
+    customer_tier               VARCHAR(20)     NOT NULL,
+    spread                      NUMERIC(10,6)   NOT NULL,
+    created_at 			            TIMESTAMPTZ,
+    updated_at 			            TIMESTAMPTZ,
+    UNIQUE (from_currency, to_currency, customer_tier)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DELETE FROM bank_exchange_spreads;
\ No newline at end of file
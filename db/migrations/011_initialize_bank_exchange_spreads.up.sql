@@ -0,0 +1,76 @@
+DELETE FROM bank_exchange_spreads;
+
+INSERT
+	INTO
+	bank_exchange_spreads (spread_uuid,
+	from_currency,
+	to_currency,
+	customer_tier,
+	spread,
+	created_at,
+	updated_at)
+VALUES('6b1d3a52-0f5e-4c8e-9d3a-1f2b7c4e8a01',
+'USD',
+'BRL',
+'DEFAULT',
+0.01,
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_exchange_spreads (spread_uuid,
+	from_currency,
+	to_currency,
+	customer_tier,
+	spread,
+	created_at,
+	updated_at)
+VALUES('0c7e2d94-5a1b-4f3c-8e6d-2b9a4c1f7e02',
+'USD',
+'BRL',
+'PREMIUM',
+0.005,
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_exchange_spreads (spread_uuid,
+	from_currency,
+	to_currency,
+	customer_tier,
+	spread,
+	created_at,
+	updated_at)
+VALUES('9f4a6c1e-2d8b-4e7a-b5c3-3d1e8f2a6b03',
+'BRL',
+'USD',
+'DEFAULT',
+0.01,
+now(),
+now())
+ON CONFLICT DO NOTHING;
+
+
+INSERT
+	INTO
+	bank_exchange_spreads (spread_uuid,
+	from_currency,
+	to_currency,
+	customer_tier,
+	spread,
+	created_at,
+	updated_at)
+VALUES('d2b8e5f1-7c3a-4b9e-a1d6-4e2f9b3c7d04',
+'BRL',
+'USD',
+'PREMIUM',
+0.005,
+now(),
+now())
+ON CONFLICT DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS bank_exchange_rates_notify ON bank_exchange_rates;
+DROP FUNCTION IF EXISTS notify_bank_exchange_rate();
\ No newline at end of file
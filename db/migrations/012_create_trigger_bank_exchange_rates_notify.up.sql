@@ -0,0 +1,19 @@
+CREATE OR REPLACE FUNCTION notify_bank_exchange_rate() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('bank_exchange_rates', json_build_object(
+        'from_currency',        NEW.from_currency,
+        'to_currency',          NEW.to_currency,
+        'rate',                 NEW.rate,
+        'valid_from_timestamp', NEW.valid_from_timestamp,
+        'valid_to_timestamp',   NEW.valid_to_timestamp
+    )::text);
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS bank_exchange_rates_notify ON bank_exchange_rates;
+
+CREATE TRIGGER bank_exchange_rates_notify
+    AFTER INSERT ON bank_exchange_rates
+    FOR EACH ROW EXECUTE FUNCTION notify_bank_exchange_rate();
\ No newline at end of file
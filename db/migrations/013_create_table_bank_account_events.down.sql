@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bank_account_events CASCADE;
+
+ALTER TABLE bank_accounts DROP COLUMN IF EXISTS event_version;
\ No newline at end of file
@@ -0,0 +1,19 @@
+-- versão por conta, atribuída sob o lock da linha da conta. A event_sequence global é
+-- alocada antes do commit e pode ficar visível fora de ordem entre transações concorrentes
+ALTER TABLE bank_accounts ADD COLUMN IF NOT EXISTS event_version BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS bank_account_events(
+    event_sequence              BIGSERIAL       PRIMARY KEY,
+    account_uuid                UUID            NOT NULL REFERENCES bank_accounts,
+    account_version             BIGINT          NOT NULL,
+    event_type                  VARCHAR(50)     NOT NULL,
+    transaction_uuid            UUID,
+    transaction_type            VARCHAR(25),
+    amount                      NUMERIC(15,2),
+    balance                     NUMERIC(15,2)   NOT NULL,
+    notes                       TEXT,
+    event_timestamp             TIMESTAMPTZ     NOT NULL,
+    created_at 			            TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_account_events_account_version ON bank_account_events (account_uuid, account_version);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bank_outbox_dead_letters CASCADE;
+DROP TABLE IF EXISTS bank_outbox_aggregates CASCADE;
+DROP TABLE IF EXISTS bank_outbox CASCADE;
\ No newline at end of file
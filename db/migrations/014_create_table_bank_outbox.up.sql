@@ -0,0 +1,41 @@
+CREATE TABLE IF NOT EXISTS bank_outbox(
+    outbox_sequence             BIGSERIAL       PRIMARY KEY,
+    event_uuid                  UUID            UNIQUE NOT NULL,
+    aggregate_type              VARCHAR(50)     NOT NULL,
+    aggregate_uuid              UUID            NOT NULL,
+    aggregate_version           BIGINT          NOT NULL,
+    event_type                  VARCHAR(50)     NOT NULL,
+    payload                     JSONB           NOT NULL,
+    attempts                    INTEGER         NOT NULL DEFAULT 0,
+    last_error                  TEXT,
+    next_attempt_at             TIMESTAMPTZ     NOT NULL,
+    published_at                TIMESTAMPTZ,
+    created_at 			            TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_outbox_pending ON bank_outbox (outbox_sequence) WHERE published_at IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_outbox_aggregate_version ON bank_outbox (aggregate_type, aggregate_uuid, aggregate_version);
+
+-- última versão de evento de cada agregado. A linha é travada por quem grava o próximo evento,
+-- então as versões de um agregado ficam visíveis na ordem em que foram atribuídas
+CREATE TABLE IF NOT EXISTS bank_outbox_aggregates(
+    aggregate_type              VARCHAR(50)     NOT NULL,
+    aggregate_uuid              UUID            NOT NULL,
+    last_version                BIGINT          NOT NULL,
+    PRIMARY KEY (aggregate_type, aggregate_uuid)
+);
+
+CREATE TABLE IF NOT EXISTS bank_outbox_dead_letters(
+    event_uuid                  UUID            PRIMARY KEY,
+    outbox_sequence             BIGINT          NOT NULL,
+    aggregate_type              VARCHAR(50)     NOT NULL,
+    aggregate_uuid              UUID            NOT NULL,
+    aggregate_version           BIGINT          NOT NULL,
+    event_type                  VARCHAR(50)     NOT NULL,
+    payload                     JSONB           NOT NULL,
+    attempts                    INTEGER         NOT NULL,
+    last_error                  TEXT,
+    failed_at                   TIMESTAMPTZ     NOT NULL,
+    created_at 			            TIMESTAMPTZ
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS bank_webhook_delivery_attempts CASCADE;
+DROP TABLE IF EXISTS bank_webhook_deliveries CASCADE;
+DROP TABLE IF EXISTS bank_webhook_subscriptions CASCADE;
\ No newline at end of file
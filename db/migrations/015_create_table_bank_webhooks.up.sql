@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS bank_webhook_subscriptions(
+    subscription_uuid           UUID            PRIMARY KEY,
+    account_uuid                UUID            NOT NULL REFERENCES bank_accounts,
+    event_type                  VARCHAR(50)     NOT NULL,
+    url                         TEXT            NOT NULL,
+    secret                      VARCHAR(128)    NOT NULL,
+    active                      BOOLEAN         NOT NULL DEFAULT TRUE,
+    created_at 			            TIMESTAMPTZ,
+    updated_at 			            TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS bank_webhook_deliveries(
+    delivery_uuid               UUID            PRIMARY KEY,
+    subscription_uuid           UUID            NOT NULL REFERENCES bank_webhook_subscriptions ON DELETE CASCADE,
+    event_uuid                  UUID            NOT NULL,
+    event_type                  VARCHAR(50)     NOT NULL,
+    payload                     JSONB           NOT NULL,
+    status                      VARCHAR(20)     NOT NULL,
+    attempts                    INTEGER         NOT NULL DEFAULT 0,
+    last_status_code            INTEGER         NOT NULL DEFAULT 0,
+    last_error                  TEXT,
+    next_attempt_at             TIMESTAMPTZ     NOT NULL,
+    delivered_at                TIMESTAMPTZ,
+    created_at 			            TIMESTAMPTZ,
+    updated_at 			            TIMESTAMPTZ,
+    UNIQUE (subscription_uuid, event_uuid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_webhook_deliveries_due ON bank_webhook_deliveries (next_attempt_at) WHERE status = 'PENDING';
+
+CREATE TABLE IF NOT EXISTS bank_webhook_delivery_attempts(
+    attempt_uuid                UUID            PRIMARY KEY,
+    delivery_uuid               UUID            NOT NULL REFERENCES bank_webhook_deliveries ON DELETE CASCADE,
+    attempt_number              INTEGER         NOT NULL,
+    status_code                 INTEGER         NOT NULL,
+    error                       TEXT,
+    duration_ms                 BIGINT          NOT NULL,
+    attempted_at                TIMESTAMPTZ     NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS bank_account_stream_events(
+    global_sequence             BIGSERIAL       PRIMARY KEY,
+    event_uuid                  UUID            UNIQUE NOT NULL,
+    account_uuid                UUID            NOT NULL,
+    stream_version              BIGINT          NOT NULL,
+    event_type                  VARCHAR(50)     NOT NULL,
+    payload                     JSONB           NOT NULL,
+    event_timestamp             TIMESTAMPTZ     NOT NULL,
+    created_at 			            TIMESTAMPTZ,
+    UNIQUE (account_uuid, stream_version)
+);
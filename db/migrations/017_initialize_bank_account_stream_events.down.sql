@@ -0,0 +1 @@
+DELETE FROM bank_account_stream_events;
@@ -0,0 +1,55 @@
+-- importa as contas existentes como streams: AccountOpened com o saldo anterior às transações
+-- registradas, seguido de um Deposited/Withdrawn por transação em ordem cronológica
+INSERT
+	INTO
+	bank_account_stream_events (event_uuid,
+	account_uuid,
+	stream_version,
+	event_type,
+	payload,
+	event_timestamp,
+	created_at)
+SELECT
+	gen_random_uuid(),
+	a.account_uuid,
+	1,
+	'AccountOpened',
+	json_build_object(
+		'account_number', a.account_number,
+		'account_name', a.account_name,
+		'currency', a.currency,
+		'opening_balance', a.current_balance - COALESCE((
+			SELECT SUM(CASE WHEN t.transaction_type = 'OUT' THEN -t.amount ELSE t.amount END)
+			FROM bank_transactions t
+			WHERE t.account_uuid = a.account_uuid), 0)
+	),
+	COALESCE(a.created_at, now()),
+	now()
+FROM
+	bank_accounts a
+ON CONFLICT DO NOTHING;
+
+INSERT
+	INTO
+	bank_account_stream_events (event_uuid,
+	account_uuid,
+	stream_version,
+	event_type,
+	payload,
+	event_timestamp,
+	created_at)
+SELECT
+	gen_random_uuid(),
+	t.account_uuid,
+	1 + ROW_NUMBER() OVER (PARTITION BY t.account_uuid ORDER BY t.transaction_timestamp, t.transaction_uuid),
+	CASE WHEN t.transaction_type = 'OUT' THEN 'Withdrawn' ELSE 'Deposited' END,
+	json_build_object(
+		'transaction_uuid', t.transaction_uuid,
+		'amount', t.amount,
+		'notes', t.notes
+	),
+	t.transaction_timestamp,
+	now()
+FROM
+	bank_transactions t
+ON CONFLICT DO NOTHING;
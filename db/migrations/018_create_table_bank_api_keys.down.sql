@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bank_api_keys CASCADE;
\ No newline at end of file
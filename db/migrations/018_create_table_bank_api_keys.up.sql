@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS bank_api_keys(
+    api_key_uuid                UUID            PRIMARY KEY,
+    key_id                      VARCHAR(32)     NOT NULL UNIQUE,
+    name                        VARCHAR(100)    NOT NULL,
+    key_hash                    CHAR(64)        NOT NULL,
+    previous_key_hash           CHAR(64),
+    previous_key_expires_at     TIMESTAMPTZ,
+    scopes                      TEXT            NOT NULL,
+    rotated_at                  TIMESTAMPTZ,
+    revoked_at                  TIMESTAMPTZ,
+    last_used_at                TIMESTAMPTZ,
+    created_at 			            TIMESTAMPTZ,
+    updated_at 			            TIMESTAMPTZ
+);
@@ -2,39 +2,69 @@ package db
 
 import (
 	"database/sql"
-	"log"
+	"errors"
+	"os"
 
 	migrate "github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/viquitorreis/my-grpc-go-server/internal/logging"
 )
 
-func Migrate(conn *sql.DB) {
-	log.Println("Starting database migration")
+var logger = logging.Logger("migrations")
+
+func newMigrate(conn *sql.DB) *migrate.Migrate {
+	driver, err := postgres.WithInstance(conn, &postgres.Config{})
+	if err != nil {
+		logger.Error("failed to create migration driver", "err", err)
+		os.Exit(1)
+	}
 
-	driver, _ := postgres.WithInstance(conn, &postgres.Config{})
 	m, err := migrate.NewWithDatabaseInstance(
 		"file://db/migrations",
 		"postgres",
 		driver,
 	)
 	if err != nil {
-		log.Fatalf("Error creating migration instance: %v", err)
+		logger.Error("failed to create migration instance", "err", err)
+		os.Exit(1)
 	}
 
-	// run all down migrations
-	if err := m.Down(); err != nil {
-		if err.Error() == "no change" {
-			log.Println("No down migration to run")
-		} else {
-			log.Fatalf("Error running down migration: %v", err)
+	return m
+}
+
+// aplica só as migrations pendentes, os dados existentes são preservados
+func Migrate(conn *sql.DB) {
+	logger.Info("starting database migration")
+
+	if err := newMigrate(conn).Up(); err != nil {
+		if !errors.Is(err, migrate.ErrNoChange) {
+			logger.Error("failed to run up migration", "err", err)
+			os.Exit(1)
 		}
+
+		logger.Info("database schema is up to date")
+		return
+	}
+
+	logger.Info("database migration completed")
+}
+
+// desfaz todas as migrations e aplica de novo, apagando todos os dados. Só pelo comando reset-database
+func Reset(conn *sql.DB) {
+	logger.Warn("resetting database, all data will be lost")
+
+	m := newMigrate(conn)
+
+	if err := m.Down(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		logger.Error("failed to run down migration", "err", err)
+		os.Exit(1)
 	}
 
-	// run all up migrations
 	if err := m.Up(); err != nil {
-		log.Fatalf("Error running up migration: %v", err)
+		logger.Error("failed to run up migration", "err", err)
+		os.Exit(1)
 	}
 
-	log.Println("Database migration completed")
+	logger.Info("database reset completed")
 }
@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime/debug"
+	"strings"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+	"github.com/viquitorreis/my-grpc-go-server/internal/logging"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+	"google.golang.org/grpc"
+	channelzservice "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/reflection"
+)
+
+var logger = logging.Logger("admin")
+
+// listener de diagnóstico separado da porta pública. Atende HTTP (pprof, build info, config)
+// e gRPC (channelz e, se grpc.reflection estiver ligado, reflection) na mesma porta, separando
+// as requisições pelo content-type
+type AdminServer struct {
+	cfg        config.AdminConfig
+	effective  config.Config
+	grpcServer *grpc.Server
+	httpServer *http.Server
+}
+
+// effective é a configuração completa do servidor, exposta em /config com os segredos mascarados
+func NewAdminServer(cfg config.AdminConfig, effective config.Config) *AdminServer {
+	a := &AdminServer{
+		cfg:        cfg,
+		effective:  effective.Redacted(),
+		grpcServer: grpc.NewServer(),
+	}
+
+	channelzservice.RegisterChannelzServiceToServer(a.grpcServer)
+
+	// segue a mesma opção da porta pública: com reflection desligado lá, o admin não expõe o schema
+	if effective.Grpc.Reflection {
+		reflection.Register(a.grpcServer)
+	}
+
+	// sem /debug/pprof/cmdline: os argumentos do processo podem trazer a DSN e outros segredos
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/buildinfo", a.handleBuildInfo)
+	mux.HandleFunc("/config", a.handleConfig)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			a.grpcServer.ServeHTTP(w, r)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+
+	// h2c permite gRPC sem TLS no mesmo listener HTTP
+	a.httpServer = &http.Server{
+		Handler: h2c.NewHandler(handler, &http2.Server{}),
+	}
+
+	return a
+}
+
+// bloqueia até o listener parar. Retorna nil quando a parada veio de Shutdown
+func (a *AdminServer) Run() error {
+	listen, err := net.Listen("tcp", a.cfg.Address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on admin address %v: %w", a.cfg.Address, err)
+	}
+
+	if host, _, _ := net.SplitHostPort(listen.Addr().String()); !net.ParseIP(host).IsLoopback() {
+		logger.Warn("admin listener is not bound to loopback, pprof and config are reachable from the network", "address", listen.Addr().String())
+	}
+
+	logger.Info("admin server running", "address", listen.Addr().String())
+
+	if err := a.httpServer.Serve(listen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve admin: %w", err)
+	}
+
+	return nil
+}
+
+func (a *AdminServer) Shutdown(ctx context.Context) {
+	// conexões h2c são sequestradas do http.Server, então o gRPC é parado à parte
+	a.grpcServer.Stop()
+
+	if err := a.httpServer.Shutdown(ctx); err != nil {
+		logger.Error("failed to shut down admin server", "err", err)
+		a.httpServer.Close()
+	}
+}
+
+type buildInfo struct {
+	GoVersion   string            `json:"go_version"`
+	Path        string            `json:"path"`
+	Version     string            `json:"version"`
+	VcsRevision string            `json:"vcs_revision,omitempty"`
+	VcsTime     string            `json:"vcs_time,omitempty"`
+	VcsModified bool              `json:"vcs_modified"`
+	Settings    map[string]string `json:"settings,omitempty"`
+}
+
+func (a *AdminServer) handleBuildInfo(w http.ResponseWriter, r *http.Request) {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		http.Error(w, "build info not available", http.StatusNotFound)
+		return
+	}
+
+	res := buildInfo{
+		GoVersion: info.GoVersion,
+		Path:      info.Main.Path,
+		Version:   info.Main.Version,
+		Settings:  make(map[string]string),
+	}
+
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			res.VcsRevision = s.Value
+		case "vcs.time":
+			res.VcsTime = s.Value
+		case "vcs.modified":
+			res.VcsModified = s.Value == "true"
+		default:
+			res.Settings[s.Key] = s.Value
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// GET /config?format=yaml|toml
+func (a *AdminServer) handleConfig(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "yaml"
+	}
+
+	var body strings.Builder
+	if err := config.Write(&body, a.effective, format); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/"+format)
+	fmt.Fprint(w, body.String())
+}
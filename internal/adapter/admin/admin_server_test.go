@@ -0,0 +1,51 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+)
+
+func TestAdminDoesNotServeCmdline(t *testing.T) {
+	cfg := config.Default()
+	a := NewAdminServer(cfg.Admin, cfg)
+
+	srv := httptest.NewServer(a.httpServer.Handler)
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/debug/pprof/cmdline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode == http.StatusOK {
+		t.Error("/debug/pprof/cmdline is served")
+	}
+
+	res, err = http.Get(srv.URL + "/debug/pprof/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("pprof index responded %v", res.Status)
+	}
+}
+
+func TestAdminReflectionFollowsGrpcConfig(t *testing.T) {
+	const reflectionService = "grpc.reflection.v1.ServerReflection"
+
+	for _, enabled := range []bool{false, true} {
+		cfg := config.Default()
+		cfg.Grpc.Reflection = enabled
+
+		_, registered := NewAdminServer(cfg.Admin, cfg).grpcServer.GetServiceInfo()[reflectionService]
+		if registered != enabled {
+			t.Errorf("reflection %v: service registered = %v", enabled, registered)
+		}
+	}
+}
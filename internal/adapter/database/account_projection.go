@@ -0,0 +1,122 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/account"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// projeção dos streams de conta nas tabelas bank_accounts e bank_transactions, mantendo
+// o mesmo formato lido pelo modo crud
+
+func projectAccountEvent(tx *gorm.DB, e account.Event) error {
+	if e.EventType == account.EventAccountOpened {
+		return upsertAccountProjection(tx, account.Account{
+			AccountUUID:   e.AccountUUID,
+			AccountNumber: e.Data.AccountNumber,
+			AccountName:   e.Data.AccountName,
+			Currency:      e.Data.Currency,
+			Balance:       e.Data.OpeningBalance,
+			OpenedAt:      e.Timestamp,
+			UpdatedAt:     e.Timestamp,
+		})
+	}
+
+	t := newProjectedTransaction(e)
+
+	if err := tx.Create(&t).Error; err != nil {
+		return fmt.Errorf("failed to project transaction %v: %w", t.TransactionUUID, err)
+	}
+
+	delta := t.Amount
+	if t.TransactionType == bank.TransactionTypeOut {
+		delta = -t.Amount
+	}
+
+	if err := tx.Model(&BankAccountOrm{}).
+		Where("account_uuid = ?", e.AccountUUID).
+		Updates(map[string]interface{}{
+			"current_balance": gorm.Expr("current_balance + ?", delta),
+			"updated_at":      time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to project account balance: %w", err)
+	}
+
+	return nil
+}
+
+func rebuildAccountProjection(tx *gorm.DB, accountUUID uuid.UUID, events []account.Event) error {
+	acc, err := account.NewAccountFromEvents(events)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild account %v: %w", accountUUID, err)
+	}
+
+	if err := upsertAccountProjection(tx, *acc); err != nil {
+		return err
+	}
+
+	if err := tx.Where("account_uuid = ?", accountUUID).Delete(&BankTransactionOrm{}).Error; err != nil {
+		return fmt.Errorf("failed to clear projected transactions: %w", err)
+	}
+
+	transactionsOrm := make([]BankTransactionOrm, 0, len(events))
+	for _, e := range events {
+		if e.EventType != account.EventAccountOpened {
+			transactionsOrm = append(transactionsOrm, newProjectedTransaction(e))
+		}
+	}
+
+	if len(transactionsOrm) == 0 {
+		return nil
+	}
+
+	if err := tx.CreateInBatches(&transactionsOrm, 500).Error; err != nil {
+		return fmt.Errorf("failed to project transactions: %w", err)
+	}
+
+	return nil
+}
+
+func upsertAccountProjection(tx *gorm.DB, acc account.Account) error {
+	accountOrm := BankAccountOrm{
+		AccountUUID:    acc.AccountUUID,
+		AccountNumber:  acc.AccountNumber,
+		AccountName:    acc.AccountName,
+		Currency:       acc.Currency,
+		CurrentBalance: acc.Balance,
+		CreatedAt:      acc.OpenedAt,
+		UpdatedAt:      time.Now(),
+	}
+
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "account_uuid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"account_number", "account_name", "currency", "current_balance", "updated_at"}),
+	}).Omit("Transactions").Create(&accountOrm).Error; err != nil {
+		return fmt.Errorf("failed to project account %v: %w", acc.AccountUUID, err)
+	}
+
+	return nil
+}
+
+func newProjectedTransaction(e account.Event) BankTransactionOrm {
+	transactionType := bank.TransactionTypeIn
+	if e.EventType == account.EventWithdrawn || e.EventType == account.EventTransferSent {
+		transactionType = bank.TransactionTypeOut
+	}
+
+	return BankTransactionOrm{
+		TransactionUUID:      e.Data.TransactionUUID,
+		AccountUUID:          e.AccountUUID,
+		TransactionTimestamp: e.Timestamp,
+		Amount:               e.Data.Amount,
+		TransactionType:      transactionType,
+		Notes:                e.Data.Notes,
+		CreatedAt:            e.Timestamp,
+		UpdatedAt:            time.Now(),
+	}
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/apikey"
+	"gorm.io/gorm"
+)
+
+func (a *DatabaseAdapter) CreateAPIKey(ctx context.Context, key APIKeyOrm) (uuid.UUID, error) {
+	if err := a.db.WithContext(ctx).Create(&key).Error; err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return key.APIKeyUUID, nil
+}
+
+func (a *DatabaseAdapter) GetAPIKeyByKeyID(ctx context.Context, keyID string) (APIKeyOrm, error) {
+	var keyOrm APIKeyOrm
+
+	if err := a.db.WithContext(ctx).First(&keyOrm, "key_id = ?", keyID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return keyOrm, apikey.ErrAPIKeyNotFound
+		}
+
+		return keyOrm, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return keyOrm, nil
+}
+
+func (a *DatabaseAdapter) GetAPIKey(ctx context.Context, apiKeyUUID uuid.UUID) (APIKeyOrm, error) {
+	var keyOrm APIKeyOrm
+
+	if err := a.db.WithContext(ctx).First(&keyOrm, "api_key_uuid = ?", apiKeyUUID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return keyOrm, apikey.ErrAPIKeyNotFound
+		}
+
+		return keyOrm, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return keyOrm, nil
+}
+
+func (a *DatabaseAdapter) ListAPIKeys(ctx context.Context) ([]APIKeyOrm, error) {
+	var keysOrm []APIKeyOrm
+
+	if err := a.db.WithContext(ctx).Order("created_at").Find(&keysOrm).Error; err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	return keysOrm, nil
+}
+
+// troca o hash da chave. O hash anterior continua aceito até previousExpiresAt, se informado
+func (a *DatabaseAdapter) RotateAPIKey(ctx context.Context, apiKeyUUID uuid.UUID, keyHash string, previousKeyHash *string,
+	previousExpiresAt *time.Time, ts time.Time) error {
+	res := a.db.WithContext(ctx).Model(&APIKeyOrm{}).
+		Where("api_key_uuid = ? AND revoked_at IS NULL", apiKeyUUID).
+		Updates(map[string]interface{}{
+			"key_hash":                keyHash,
+			"previous_key_hash":       previousKeyHash,
+			"previous_key_expires_at": previousExpiresAt,
+			"rotated_at":              ts,
+			"updated_at":              ts,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to rotate api key: %w", res.Error)
+	}
+
+	if res.RowsAffected == 0 {
+		return apikey.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) RevokeAPIKey(ctx context.Context, apiKeyUUID uuid.UUID, ts time.Time) error {
+	res := a.db.WithContext(ctx).Model(&APIKeyOrm{}).
+		Where("api_key_uuid = ? AND revoked_at IS NULL", apiKeyUUID).
+		Updates(map[string]interface{}{
+			"revoked_at": ts,
+			"updated_at": ts,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to revoke api key: %w", res.Error)
+	}
+
+	if res.RowsAffected == 0 {
+		return apikey.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) TouchAPIKey(ctx context.Context, apiKeyUUID uuid.UUID, ts time.Time) error {
+	if err := a.db.WithContext(ctx).Model(&APIKeyOrm{}).
+		Where("api_key_uuid = ?", apiKeyUUID).
+		UpdateColumn("last_used_at", ts).Error; err != nil {
+		return fmt.Errorf("failed to update api key last use: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scopes são gravados separados por espaço, como no claim scope do OAuth
+type APIKeyOrm struct {
+	APIKeyUUID           uuid.UUID `gorm:"primaryKey;column:api_key_uuid"`
+	KeyID                string
+	Name                 string
+	KeyHash              string
+	PreviousKeyHash      *string
+	PreviousKeyExpiresAt *time.Time
+	Scopes               string
+	RotatedAt            *time.Time
+	RevokedAt            *time.Time
+	LastUsedAt           *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+func (APIKeyOrm) TableName() string {
+	return "bank_api_keys"
+}
@@ -1,39 +1,41 @@
 package database
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-func (a *DatabaseAdapter) GetBankAccountNumber(account string) (BankAccountOrm, error) {
+func (a *DatabaseAdapter) GetBankAccountNumber(ctx context.Context, account string) (BankAccountOrm, error) {
 	var bankAccountOrm BankAccountOrm
-	if err := a.db.First(&bankAccountOrm, "account_number = ?", account).Error; err != nil {
-		log.Printf("failed to get bank account number: %v\n", err)
+	if err := a.db.WithContext(ctx).First(&bankAccountOrm, "account_number = ?", account).Error; err != nil {
+		logger.WarnContext(ctx, "failed to get bank account", "account_number", account, "err", err)
 		return bankAccountOrm, fmt.Errorf("failed to get bank account number: %w", err)
 	}
 
 	return bankAccountOrm, nil
 }
 
-func (a *DatabaseAdapter) CreateExchangeRate(r BankExchangeRateOrm) (uuid.UUID, error) {
-	if err := a.db.Create(&r).Error; err != nil {
-		log.Printf("failed to create exchange rate: %v\n", err)
+func (a *DatabaseAdapter) CreateExchangeRate(ctx context.Context, r BankExchangeRateOrm) (uuid.UUID, error) {
+	if err := a.db.WithContext(ctx).Create(&r).Error; err != nil {
+		logger.ErrorContext(ctx, "failed to create exchange rate", "from_currency", r.FromCurrency, "to_currency", r.ToCurrency, "err", err)
 		return uuid.Nil, fmt.Errorf("failed to create exchange rate: %w", err)
 	}
 
 	return r.ExchangeRateUUID, nil
 }
 
-func (a *DatabaseAdapter) GetExchangeRate(fromCurrency, toCurrency string, ts time.Time) (BankExchangeRateOrm, error) {
+func (a *DatabaseAdapter) GetExchangeRate(ctx context.Context, fromCurrency, toCurrency string, ts time.Time) (BankExchangeRateOrm, error) {
 	var exchangeRateOrm BankExchangeRateOrm
 	startTime := ts.AddDate(-1, 0, 0) // 1 ano no passado
 
-	err := a.db.Where(`
+	// prioriza a taxa vigente em ts, senão usa a mais recente do último ano
+	res := a.db.WithContext(ctx).Where(`
 		from_currency = ?
 		AND to_currency = ? 
 		AND (? BETWEEN valid_from_timestamp AND valid_to_timestamp)
@@ -43,108 +45,359 @@ func (a *DatabaseAdapter) GetExchangeRate(fromCurrency, toCurrency string, ts ti
 		AND to_currency = ? 
 		AND valid_from_timestamp >= ?
 	`, fromCurrency, toCurrency, startTime).
-		Find(&exchangeRateOrm).Error
+		Order(clause.OrderBy{
+			Expression: clause.Expr{
+				SQL:  "(? BETWEEN valid_from_timestamp AND valid_to_timestamp) DESC, valid_from_timestamp DESC",
+				Vars: []interface{}{ts},
+			},
+		}).
+		Limit(1).
+		Find(&exchangeRateOrm)
+
+	if res.Error != nil {
+		logger.ErrorContext(ctx, "failed to get exchange rate", "from_currency", fromCurrency, "to_currency", toCurrency, "err", res.Error)
+		return exchangeRateOrm, fmt.Errorf("failed to get exchange rate: %w", res.Error)
+	}
+
+	if res.RowsAffected == 0 {
+		return exchangeRateOrm, bank.ErrExchangeRateNotFound
+	}
+
+	return exchangeRateOrm, nil
+}
+
+// série de taxas do par entre start e end, paginada pelo par (valid_from_timestamp, exchange_rate_uuid)
+func (a *DatabaseAdapter) GetExchangeRateHistory(ctx context.Context, fromCurrency, toCurrency string, start, end time.Time,
+	afterTimestamp time.Time, afterUUID uuid.UUID, limit int) ([]BankExchangeRateOrm, error) {
+	var exchangeRatesOrm []BankExchangeRateOrm
+
+	query := a.db.WithContext(ctx).Where(`
+		from_currency = ?
+		AND to_currency = ?
+		AND valid_from_timestamp >= ?
+		AND valid_from_timestamp < ?
+	`, fromCurrency, toCurrency, start, end)
+
+	if afterUUID != uuid.Nil {
+		query = query.Where("(valid_from_timestamp, exchange_rate_uuid) > (?, ?)", afterTimestamp, afterUUID)
+	}
+
+	if err := query.Order("valid_from_timestamp, exchange_rate_uuid").
+		Limit(limit).
+		Find(&exchangeRatesOrm).Error; err != nil {
+		logger.ErrorContext(ctx, "failed to get exchange rate history", "from_currency", fromCurrency, "to_currency", toCurrency, "err", err)
+		return nil, fmt.Errorf("failed to get exchange rate history: %w", err)
+	}
+
+	return exchangeRatesOrm, nil
+}
+
+// candles OHLC calculados no postgres, truncando valid_from_timestamp em UTC pela unidade do intervalo
+func (a *DatabaseAdapter) GetExchangeRateCandles(ctx context.Context, fromCurrency, toCurrency, truncUnit string, start, end time.Time,
+	limit int) ([]BankExchangeRateCandleOrm, error) {
+	var candlesOrm []BankExchangeRateCandleOrm
+
+	err := a.db.WithContext(ctx).Raw(`
+		SELECT
+			date_trunc(?, valid_from_timestamp AT TIME ZONE 'UTC') AS bucket,
+			(array_agg(rate ORDER BY valid_from_timestamp ASC))[1] AS open,
+			MAX(rate) AS high,
+			MIN(rate) AS low,
+			(array_agg(rate ORDER BY valid_from_timestamp DESC))[1] AS close,
+			COUNT(*) AS samples
+		FROM bank_exchange_rates
+		WHERE from_currency = ?
+			AND to_currency = ?
+			AND valid_from_timestamp >= ?
+			AND valid_from_timestamp < ?
+		GROUP BY bucket
+		ORDER BY bucket
+		LIMIT ?
+	`, truncUnit, fromCurrency, toCurrency, start, end, limit).
+		Scan(&candlesOrm).Error
 
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			log.Printf("Exchange rate not found for %s to %s at %s\n", fromCurrency, toCurrency, ts)
-			// Handle the not found case specifically if needed
-		} else {
-			log.Printf("Database error: %v\n", err)
-		}
+		logger.ErrorContext(ctx, "failed to get exchange rate candles", "from_currency", fromCurrency, "to_currency", toCurrency, "err", err)
+		return nil, fmt.Errorf("failed to get exchange rate candles: %w", err)
 	}
 
-	return exchangeRateOrm, err
+	return candlesOrm, nil
 }
 
-func (a *DatabaseAdapter) CreateTransaction(account BankAccountOrm, t BankTransactionOrm) (uuid.UUID, error) {
-	tx := a.db.Begin() // começa a transação
+func (a *DatabaseAdapter) GetExchangeSpread(ctx context.Context, fromCurrency, toCurrency, customerTier string) (BankExchangeSpreadOrm, error) {
+	var spreadOrm BankExchangeSpreadOrm
 
-	if err := tx.Create(&t).Error; err != nil {
-		tx.Rollback() // rollback da transação caso ocorra erro
-		return uuid.Nil, err
+	// spread específico do tier tem precedência sobre o spread DEFAULT do par
+	res := a.db.WithContext(ctx).Where(`
+		from_currency = ?
+		AND to_currency = ?
+		AND customer_tier IN (?, ?)
+	`, fromCurrency, toCurrency, customerTier, bank.CustomerTierDefault).
+		Order(clause.OrderBy{
+			Expression: clause.Expr{
+				SQL:  "customer_tier = ? DESC",
+				Vars: []interface{}{customerTier},
+			},
+		}).
+		Limit(1).
+		Find(&spreadOrm)
+
+	if res.Error != nil {
+		return spreadOrm, fmt.Errorf("failed to get exchange spread: %w", res.Error)
 	}
 
-	// recalcula o saldo da conta
-	newAmount := t.Amount
+	if res.RowsAffected == 0 {
+		return spreadOrm, bank.ErrExchangeSpreadNotFound
+	}
 
-	if t.TransactionType == bank.TransactionTypeOut {
-		newAmount = -1 * t.Amount
+	return spreadOrm, nil
+}
+
+func (a *DatabaseAdapter) CreateExchangeQuote(ctx context.Context, q BankExchangeQuoteOrm) (uuid.UUID, error) {
+	if err := a.db.WithContext(ctx).Create(&q).Error; err != nil {
+		logger.ErrorContext(ctx, "failed to create exchange quote", "quote_uuid", q.QuoteUUID, "err", err)
+		return uuid.Nil, fmt.Errorf("failed to create exchange quote: %w", err)
 	}
 
-	newAccountBalance := account.CurrentBalance + newAmount
+	return q.QuoteUUID, nil
+}
 
-	// atualizando o saldo da conta e o updated_at
-	if err := tx.Model(&account).Updates(
-		map[string]interface{}{
-			"current_balance": newAccountBalance,
-			"updated_at":      time.Now(),
-		},
-	).Error; err != nil {
-		tx.Rollback()
-		return uuid.Nil, err
+func (a *DatabaseAdapter) GetExchangeQuote(ctx context.Context, quoteUUID uuid.UUID) (BankExchangeQuoteOrm, error) {
+	var quoteOrm BankExchangeQuoteOrm
+	if err := a.db.WithContext(ctx).First(&quoteOrm, "quote_uuid = ?", quoteUUID).Error; err != nil {
+		return quoteOrm, fmt.Errorf("failed to get exchange quote: %w", err)
 	}
 
-	tx.Commit() // commit da transação
+	return quoteOrm, nil
+}
 
-	return t.AccountUUID, nil
+// marca a cotação como usada apenas se ainda estiver válida e não tiver sido usada, garantindo que duas
+// transferências concorrentes não consumam a mesma cotação. Roda na transação que grava a transferência,
+// então uma transferência desfeita não gasta a cotação. Nil quando a transferência não tem cotação
+func consumeExchangeQuote(tx *gorm.DB, quoteUUID uuid.UUID, ts time.Time) error {
+	if quoteUUID == uuid.Nil {
+		return nil
+	}
+
+	res := tx.Model(&BankExchangeQuoteOrm{}).
+		Where("quote_uuid = ? AND used_at IS NULL AND expires_at > ?", quoteUUID, ts).
+		Updates(map[string]interface{}{
+			"used_at":    ts,
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to use exchange quote: %w", res.Error)
+	}
+
+	// a validade já foi conferida com o mesmo timestamp, então nenhuma linha alterada significa cotação já usada
+	if res.RowsAffected != 1 {
+		return bank.ErrExchangeQuoteUsed
+	}
+
+	return nil
 }
 
-func (a *DatabaseAdapter) CreateTransfer(transfer BankTransferOrm) (uuid.UUID, error) {
-	if err := a.db.Create(&transfer).Error; err != nil {
+func (a *DatabaseAdapter) CreateTransaction(ctx context.Context, account BankAccountOrm, t BankTransactionOrm) (uuid.UUID, error) {
+	// Transaction em vez de Begin para funcionar também dentro de WithTransaction (vira savepoint)
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&t).Error; err != nil {
+			return err
+		}
+
+		newAccountBalance, err := applyAccountBalance(tx, t)
+		if err != nil {
+			return err
+		}
+
+		return recordTransactionPosted(tx, t, newAccountBalance)
+	})
+	if err != nil {
 		return uuid.Nil, err
 	}
 
-	return transfer.TransferUUID, nil
+	return t.AccountUUID, nil
 }
 
-func (a *DatabaseAdapter) CreateTransferTransactionPair(fromAccountOrm BankAccountOrm, toAccountOrm BankAccountOrm,
-	fromTransactionOrm BankTransactionOrm, toTransactionOrm BankTransactionOrm) (bool, error) {
-	tx := a.db.Begin()
+// aplica o lançamento no saldo direto no banco e devolve o saldo resultante. O cálculo a partir de uma
+// leitura anterior perde atualizações concorrentes; o UPDATE segura o lock da linha até o commit.
+// Um débito que deixaria o saldo negativo não altera a linha e volta ErrTransactionInsufficientFunds
+func applyAccountBalance(tx *gorm.DB, t BankTransactionOrm) (float64, error) {
+	delta := t.Amount
+	if t.TransactionType == bank.TransactionTypeOut {
+		delta = -1 * t.Amount
+	}
+
+	query := `
+		UPDATE bank_accounts
+		SET current_balance = current_balance + ?, updated_at = ?
+		WHERE account_uuid = ?`
+	args := []interface{}{delta, time.Now(), t.AccountUUID}
 
-	if err := tx.Create(&fromTransactionOrm).Error; err != nil {
-		tx.Rollback()
-		return false, err
+	if delta < 0 {
+		query += " AND current_balance + ? >= 0"
+		args = append(args, delta)
 	}
 
-	if err := tx.Create(&toTransactionOrm).Error; err != nil {
-		tx.Rollback()
-		return false, err
+	var balance float64
+
+	res := tx.Raw(query+" RETURNING current_balance", args...).Scan(&balance)
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to update account balance: %w", res.Error)
 	}
 
-	// recalculando o balanço da conta de origem
-	fromAccNewBal := fromAccountOrm.CurrentBalance - fromTransactionOrm.Amount
+	if res.RowsAffected == 0 {
+		if delta < 0 {
+			return 0, fmt.Errorf("%w: account %v", bank.ErrTransactionInsufficientFunds, t.AccountUUID)
+		}
 
-	if err := tx.Model(&fromAccountOrm).Updates(
-		map[string]interface{}{
-			"current_balance": fromAccNewBal,
-			"updated_at":      time.Now(),
-		},
-	).Error; err != nil {
-		tx.Rollback()
-		return false, err
+		return 0, fmt.Errorf("failed to update account balance: account %v not found", t.AccountUUID)
+	}
+
+	return balance, nil
+}
+
+// evento da conta e outbox do lançamento, gravados na mesma transação que altera o saldo
+func recordTransactionPosted(tx *gorm.DB, t BankTransactionOrm, balance float64) error {
+	version, err := nextAccountEventVersion(tx, t.AccountUUID)
+	if err != nil {
+		return err
 	}
 
-	// recalculando o balanço da conta de destino
-	toAccNewBal := toAccountOrm.CurrentBalance + toTransactionOrm.Amount
+	if err := tx.Create(newTransactionPostedEvent(t, version, balance)).Error; err != nil {
+		return err
+	}
 
-	if err := tx.Model(&toAccountOrm).Updates(
+	return createOutboxEvent(tx, bank.AggregateTypeAccount, t.AccountUUID, bank.EventTypeTransactionCreated,
+		newTransactionCreatedPayload(t, balance))
+}
+
+func completeTransfer(tx *gorm.DB, transferOrm BankTransferOrm, fromTransactionOrm BankTransactionOrm, toTransactionOrm BankTransactionOrm) error {
+	if err := tx.Model(&transferOrm).Updates(
 		map[string]interface{}{
-			"current_balance": toAccNewBal,
-			"updated_at":      time.Now(),
+			"transfer_success": true,
+			"updated_at":       time.Now(),
 		},
 	).Error; err != nil {
-		tx.Rollback()
-		return false, err
+		return err
+	}
+
+	return createOutboxEvent(tx, bank.AggregateTypeTransfer, transferOrm.TransferUUID, bank.EventTypeTransferCompleted,
+		bank.TransferCompletedPayload{
+			TransferUUID:        transferOrm.TransferUUID,
+			FromAccountUUID:     fromTransactionOrm.AccountUUID,
+			ToAccountUUID:       toTransactionOrm.AccountUUID,
+			FromTransactionUUID: fromTransactionOrm.TransactionUUID,
+			ToTransactionUUID:   toTransactionOrm.TransactionUUID,
+			Currency:            transferOrm.Currency,
+			AmountOut:           fromTransactionOrm.Amount,
+			AmountIn:            toTransactionOrm.Amount,
+			Timestamp:           transferOrm.TransferTimestamp,
+		})
+}
+
+// incrementa a versão na linha da conta. O UPDATE segura o lock da linha até o commit, então a
+// próxima transação da mesma conta só recebe a versão seguinte depois que esta ficar visível
+// e a stream nunca pula uma versão menor ainda não commitada
+func nextAccountEventVersion(tx *gorm.DB, accountUUID uuid.UUID) (int64, error) {
+	var version int64
+
+	res := tx.Raw(`
+		UPDATE bank_accounts
+		SET event_version = event_version + 1
+		WHERE account_uuid = ?
+		RETURNING event_version`, accountUUID).Scan(&version)
+	if res.Error != nil {
+		return 0, fmt.Errorf("failed to assign account event version: %w", res.Error)
+	}
+
+	if res.RowsAffected == 0 {
+		return 0, fmt.Errorf("failed to assign account event version: account %v not found", accountUUID)
 	}
 
-	tx.Commit()
+	return version, nil
+}
+
+func newTransactionPostedEvent(t BankTransactionOrm, version int64, balance float64) *BankAccountEventOrm {
+	return &BankAccountEventOrm{
+		AccountUUID:     t.AccountUUID,
+		AccountVersion:  version,
+		EventType:       bank.AccountEventTransactionPosted,
+		TransactionUUID: t.TransactionUUID,
+		TransactionType: t.TransactionType,
+		Amount:          t.Amount,
+		Balance:         balance,
+		Notes:           t.Notes,
+		EventTimestamp:  t.TransactionTimestamp,
+		CreatedAt:       time.Now(),
+	}
+}
+
+func (a *DatabaseAdapter) GetAccountEvents(ctx context.Context, accountUUID uuid.UUID, afterVersion int64, limit int) ([]BankAccountEventOrm, error) {
+	var eventsOrm []BankAccountEventOrm
+
+	if err := a.db.WithContext(ctx).Where("account_uuid = ? AND account_version > ?", accountUUID, afterVersion).
+		Order("account_version").
+		Limit(limit).
+		Find(&eventsOrm).Error; err != nil {
+		return nil, fmt.Errorf("failed to get account events: %w", err)
+	}
+
+	return eventsOrm, nil
+}
+
+func (a *DatabaseAdapter) CreateTransfer(ctx context.Context, transfer BankTransferOrm) (uuid.UUID, error) {
+	if err := a.db.WithContext(ctx).Create(&transfer).Error; err != nil {
+		return uuid.Nil, err
+	}
+
+	return transfer.TransferUUID, nil
+}
+
+// grava a cotação, os dois lançamentos, os saldos, o status da transferência e os eventos numa única transação
+func (a *DatabaseAdapter) CreateTransferTransactionPair(ctx context.Context, transferOrm BankTransferOrm, fromAccountOrm BankAccountOrm, toAccountOrm BankAccountOrm,
+	fromTransactionOrm BankTransactionOrm, toTransactionOrm BankTransactionOrm, quoteUUID uuid.UUID) (bool, error) {
+	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := consumeExchangeQuote(tx, quoteUUID, transferOrm.TransferTimestamp); err != nil {
+			return err
+		}
+
+		if err := tx.Create(&fromTransactionOrm).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&toTransactionOrm).Error; err != nil {
+			return err
+		}
+
+		fromAccNewBal, err := applyAccountBalance(tx, fromTransactionOrm)
+		if err != nil {
+			return err
+		}
+
+		toAccNewBal, err := applyAccountBalance(tx, toTransactionOrm)
+		if err != nil {
+			return err
+		}
+
+		if err := recordTransactionPosted(tx, fromTransactionOrm, fromAccNewBal); err != nil {
+			return err
+		}
+
+		if err := recordTransactionPosted(tx, toTransactionOrm, toAccNewBal); err != nil {
+			return err
+		}
+
+		return completeTransfer(tx, transferOrm, fromTransactionOrm, toTransactionOrm)
+	})
+	if err != nil {
+		return false, err
+	}
 
 	return true, nil
 }
 
-func (a *DatabaseAdapter) UpdateTransferStatus(transfer BankTransferOrm, status bool) error {
-	if err := a.db.Model(&transfer).Updates(
+func (a *DatabaseAdapter) UpdateTransferStatus(ctx context.Context, transfer BankTransferOrm, status bool) error {
+	if err := a.db.WithContext(ctx).Model(&transfer).Updates(
 		map[string]interface{}{
 			"transfer_success": status,
 			"updated_at":       time.Now(),
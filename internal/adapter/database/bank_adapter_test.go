@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+func TestConcurrentPostingsKeepEveryUpdate(t *testing.T) {
+	es := newTestEventStore(t)
+	a := es.DatabaseAdapter
+	id := openTestAccount(t, es)
+
+	// todos os writers partem da mesma leitura, como o service faz antes de gravar
+	stale := BankAccountOrm{AccountUUID: id, CurrentBalance: 100}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+
+	for i := range errs {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			_, errs[i] = a.CreateTransaction(context.Background(), stale, newTestDeposit(id, 10))
+		}()
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("writer %d: %v", i, err)
+		}
+	}
+
+	var acc BankAccountOrm
+	if err := a.db.Where("account_uuid = ?", id).First(&acc).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if acc.CurrentBalance != 200 {
+		t.Errorf("got balance %v, want 200", acc.CurrentBalance)
+	}
+
+	events, err := a.GetAccountEvents(context.Background(), id, 0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) == 0 || events[len(events)-1].Balance != 200 {
+		t.Errorf("last account event does not carry the final balance: %+v", events)
+	}
+}
+
+func TestPostingRejectsOverdraft(t *testing.T) {
+	es := newTestEventStore(t)
+	a := es.DatabaseAdapter
+	id := openTestAccount(t, es)
+
+	withdrawal := newTestDeposit(id, 150)
+	withdrawal.TransactionType = bank.TransactionTypeOut
+
+	// o snapshot diz que há saldo, mas o saldo real é 100
+	stale := BankAccountOrm{AccountUUID: id, CurrentBalance: 500}
+
+	if _, err := a.CreateTransaction(context.Background(), stale, withdrawal); !errors.Is(err, bank.ErrTransactionInsufficientFunds) {
+		t.Fatalf("got %v, want ErrTransactionInsufficientFunds", err)
+	}
+
+	var count int64
+	if err := a.db.Model(&BankTransactionOrm{}).Where("transaction_uuid = ?", withdrawal.TransactionUUID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 0 {
+		t.Errorf("rejected withdrawal was recorded")
+	}
+}
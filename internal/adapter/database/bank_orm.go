@@ -36,6 +36,61 @@ func (BankTransactionOrm) TableName() string {
 	return "bank_transactions"
 }
 
+type BankAccountEventOrm struct {
+	EventSequence   int64 `gorm:"primaryKey;autoIncrement"`
+	AccountUUID     uuid.UUID
+	AccountVersion  int64
+	EventType       string
+	TransactionUUID uuid.UUID
+	TransactionType string
+	Amount          float64
+	Balance         float64
+	Notes           string
+	EventTimestamp  time.Time
+	CreatedAt       time.Time
+}
+
+func (BankAccountEventOrm) TableName() string {
+	return "bank_account_events"
+}
+
+type BankOutboxOrm struct {
+	OutboxSequence   int64 `gorm:"primaryKey;autoIncrement"`
+	EventUUID        uuid.UUID
+	AggregateType    string
+	AggregateUUID    uuid.UUID
+	AggregateVersion int64
+	EventType        string
+	Payload          string `gorm:"type:jsonb"`
+	Attempts         int
+	LastError        string
+	NextAttemptAt    time.Time
+	PublishedAt      *time.Time
+	CreatedAt        time.Time
+}
+
+func (BankOutboxOrm) TableName() string {
+	return "bank_outbox"
+}
+
+type BankOutboxDeadLetterOrm struct {
+	EventUUID        uuid.UUID `gorm:"primaryKey"`
+	OutboxSequence   int64
+	AggregateType    string
+	AggregateUUID    uuid.UUID
+	AggregateVersion int64
+	EventType        string
+	Payload          string `gorm:"type:jsonb"`
+	Attempts         int
+	LastError        string
+	FailedAt         time.Time
+	CreatedAt        time.Time
+}
+
+func (BankOutboxDeadLetterOrm) TableName() string {
+	return "bank_outbox_dead_letters"
+}
+
 type BankExchangeRateOrm struct {
 	ExchangeRateUUID   uuid.UUID `gorm:"primaryKey"`
 	FromCurrency       string
@@ -51,6 +106,47 @@ func (BankExchangeRateOrm) TableName() string {
 	return "bank_exchange_rates"
 }
 
+// resultado da agregação OHLC, não mapeia nenhuma tabela
+type BankExchangeRateCandleOrm struct {
+	Bucket  time.Time
+	Open    float64
+	High    float64
+	Low     float64
+	Close   float64
+	Samples int64
+}
+
+type BankExchangeSpreadOrm struct {
+	SpreadUUID   uuid.UUID `gorm:"primaryKey"`
+	FromCurrency string
+	ToCurrency   string
+	CustomerTier string
+	Spread       float64
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (BankExchangeSpreadOrm) TableName() string {
+	return "bank_exchange_spreads"
+}
+
+type BankExchangeQuoteOrm struct {
+	QuoteUUID    uuid.UUID `gorm:"primaryKey"`
+	FromCurrency string
+	ToCurrency   string
+	MidRate      float64
+	Spread       float64
+	Rate         float64
+	ExpiresAt    time.Time
+	UsedAt       *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (BankExchangeQuoteOrm) TableName() string {
+	return "bank_exchange_quotes"
+}
+
 type BankTransferOrm struct {
 	TransferUUID      uuid.UUID `gorm:"primaryKey"`
 	FromAccountUUID   uuid.UUID
@@ -66,3 +162,18 @@ type BankTransferOrm struct {
 func (BankTransferOrm) TableName() string {
 	return "bank_transfers"
 }
+
+type BankAccountStreamEventOrm struct {
+	GlobalSequence int64 `gorm:"primaryKey;autoIncrement"`
+	EventUUID      uuid.UUID
+	AccountUUID    uuid.UUID
+	StreamVersion  int64
+	EventType      string
+	Payload        string `gorm:"type:jsonb"`
+	EventTimestamp time.Time
+	CreatedAt      time.Time
+}
+
+func (BankAccountStreamEventOrm) TableName() string {
+	return "bank_account_stream_events"
+}
@@ -1,24 +1,49 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
+	"github.com/lib/pq"
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+	"github.com/viquitorreis/my-grpc-go-server/internal/logging"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+var logger = logging.Logger("database")
+
 type DatabaseAdapter struct {
 	db *gorm.DB
+	// COPY só está disponível com o driver lib/pq
+	copyIn bool
 }
 
-func NewDatabaseAdapter(conn *sql.DB) (*DatabaseAdapter, error) {
+func NewDatabaseAdapter(conn *sql.DB, cfg config.DatabaseConfig) (*DatabaseAdapter, error) {
+	conn.SetMaxOpenConns(cfg.MaxOpenConns)
+	conn.SetMaxIdleConns(cfg.MaxIdleConns)
+	conn.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
 	db, err := gorm.Open(postgres.New(postgres.Config{
 		Conn: conn,
-	}), &gorm.Config{})
+	}), &gorm.Config{
+		Logger: newGormLogger(logger),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
 
-	return &DatabaseAdapter{db: db}, nil
+	_, copyIn := conn.Driver().(*pq.Driver)
+
+	return &DatabaseAdapter{db: db, copyIn: copyIn}, nil
+}
+
+func (a *DatabaseAdapter) Ping(ctx context.Context) error {
+	conn, err := a.db.DB()
+	if err != nil {
+		return err
+	}
+
+	return conn.PingContext(ctx)
 }
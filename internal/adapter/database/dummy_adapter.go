@@ -1,14 +1,12 @@
 package database
 
 import (
-	"log"
-
 	"github.com/google/uuid"
 )
 
 func (a *DatabaseAdapter) Save(data *DummyOrm) (uuid.UUID, error) {
 	if err := a.db.Create(data).Error; err != nil {
-		log.Println("Cant create data", err)
+		logger.Error("failed to create dummy data", "err", err)
 		return uuid.Nil, err
 	}
 
@@ -18,7 +16,7 @@ func (a *DatabaseAdapter) Save(data *DummyOrm) (uuid.UUID, error) {
 func (a *DatabaseAdapter) GetByUUID(uuid uuid.UUID) (DummyOrm, error) {
 	var res DummyOrm
 	if err := a.db.First(&res, "user_id = ?", uuid).Error; err != nil {
-		log.Println("Cant get data", err)
+		logger.Error("failed to get dummy data", "user_id", uuid, "err", err)
 		return res, err
 	}
 
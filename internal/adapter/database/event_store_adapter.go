@@ -0,0 +1,257 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/account"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"gorm.io/gorm"
+)
+
+// tentativas de um comando quando outro writer altera o mesmo stream entre a leitura e o append
+const maxStreamAppendAttempts = 3
+
+// unique (account_uuid, stream_version) da migration 016, com o nome gerado pelo postgres
+const streamVersionConstraint = "bank_account_stream_events_account_uuid_stream_version_key"
+
+// modo de armazenamento alternativo: os lançamentos viram eventos no stream da conta e
+// bank_accounts/bank_transactions passam a ser projeções. As leituras continuam nas tabelas
+// do DatabaseAdapter, que são atualizadas na mesma transação do append
+type EventSourcedDatabaseAdapter struct {
+	*DatabaseAdapter
+}
+
+func NewEventSourcedDatabaseAdapter(a *DatabaseAdapter) *EventSourcedDatabaseAdapter {
+	return &EventSourcedDatabaseAdapter{DatabaseAdapter: a}
+}
+
+// o saldo vem do stream e não do snapshot lido pelo service, que pode estar desatualizado
+func (a *EventSourcedDatabaseAdapter) CreateTransaction(ctx context.Context, accountOrm BankAccountOrm, t BankTransactionOrm) (uuid.UUID, error) {
+	err := a.withStreamRetry(ctx, func(tx *gorm.DB) error {
+		acc, err := loadAccountStream(tx, t.AccountUUID)
+		if err != nil {
+			return err
+		}
+
+		if t.TransactionType == bank.TransactionTypeOut {
+			err = acc.Withdraw(t.TransactionUUID, t.Amount, t.Notes, t.TransactionTimestamp)
+		} else {
+			err = acc.Deposit(t.TransactionUUID, t.Amount, t.Notes, t.TransactionTimestamp)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := appendAccountEvents(tx, acc); err != nil {
+			return err
+		}
+
+		return recordTransactionPosted(tx, t, acc.Balance)
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	return t.AccountUUID, nil
+}
+
+func (a *EventSourcedDatabaseAdapter) CreateTransferTransactionPair(ctx context.Context, transferOrm BankTransferOrm, fromAccountOrm BankAccountOrm, toAccountOrm BankAccountOrm,
+	fromTransactionOrm BankTransactionOrm, toTransactionOrm BankTransactionOrm, quoteUUID uuid.UUID) (bool, error) {
+	err := a.withStreamRetry(ctx, func(tx *gorm.DB) error {
+		if err := consumeExchangeQuote(tx, quoteUUID, transferOrm.TransferTimestamp); err != nil {
+			return err
+		}
+
+		fromAcc, err := loadAccountStream(tx, fromAccountOrm.AccountUUID)
+		if err != nil {
+			return err
+		}
+
+		// transferência para a própria conta: os dois eventos vão para o mesmo stream
+		toAcc := fromAcc
+		if toAccountOrm.AccountUUID != fromAccountOrm.AccountUUID {
+			if toAcc, err = loadAccountStream(tx, toAccountOrm.AccountUUID); err != nil {
+				return err
+			}
+		}
+
+		if err := fromAcc.SendTransfer(transferOrm.TransferUUID, fromTransactionOrm.TransactionUUID, toAcc.AccountUUID,
+			fromTransactionOrm.Amount, fromTransactionOrm.Notes, fromTransactionOrm.TransactionTimestamp); err != nil {
+			return err
+		}
+
+		fromBalance := fromAcc.Balance
+
+		if err := toAcc.ReceiveTransfer(transferOrm.TransferUUID, toTransactionOrm.TransactionUUID, fromAcc.AccountUUID,
+			toTransactionOrm.Amount, toTransactionOrm.Notes, toTransactionOrm.TransactionTimestamp); err != nil {
+			return err
+		}
+
+		if err := appendAccountEvents(tx, fromAcc); err != nil {
+			return err
+		}
+
+		if toAcc != fromAcc {
+			if err := appendAccountEvents(tx, toAcc); err != nil {
+				return err
+			}
+		}
+
+		if err := recordTransactionPosted(tx, fromTransactionOrm, fromBalance); err != nil {
+			return err
+		}
+
+		if err := recordTransactionPosted(tx, toTransactionOrm, toAcc.Balance); err != nil {
+			return err
+		}
+
+		return completeTransfer(tx, transferOrm, fromTransactionOrm, toTransactionOrm)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to create transfer transaction pair: %w", err)
+	}
+
+	return true, nil
+}
+
+func (a *EventSourcedDatabaseAdapter) GetAccountStream(accountUUID uuid.UUID) ([]account.Event, error) {
+	return getAccountStreamEvents(a.db, accountUUID)
+}
+
+// apaga e recria as projeções de todas as contas a partir dos streams, em uma única transação.
+// Lançamentos gravados fora do event store (modo crud) são descartados
+func (a *EventSourcedDatabaseAdapter) RebuildAccountProjections() (int, error) {
+	rebuilt := 0
+
+	err := a.db.Transaction(func(tx *gorm.DB) error {
+		var accountUUIDs []uuid.UUID
+		if err := tx.Model(&BankAccountStreamEventOrm{}).Distinct("account_uuid").Pluck("account_uuid", &accountUUIDs).Error; err != nil {
+			return fmt.Errorf("failed to list account streams: %w", err)
+		}
+
+		for _, id := range accountUUIDs {
+			events, err := getAccountStreamEvents(tx, id)
+			if err != nil {
+				return err
+			}
+
+			if err := rebuildAccountProjection(tx, id, events); err != nil {
+				return err
+			}
+
+			rebuilt++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebuild account projections: %w", err)
+	}
+
+	return rebuilt, nil
+}
+
+func (a *EventSourcedDatabaseAdapter) withStreamRetry(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	var err error
+
+	for attempt := 0; attempt < maxStreamAppendAttempts; attempt++ {
+		err = a.db.WithContext(ctx).Transaction(fn)
+		if !errors.Is(err, account.ErrStreamVersionConflict) {
+			return err
+		}
+	}
+
+	return err
+}
+
+func loadAccountStream(tx *gorm.DB, accountUUID uuid.UUID) (*account.Account, error) {
+	events, err := getAccountStreamEvents(tx, accountUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.NewAccountFromEvents(events)
+}
+
+func getAccountStreamEvents(tx *gorm.DB, accountUUID uuid.UUID) ([]account.Event, error) {
+	var eventsOrm []BankAccountStreamEventOrm
+
+	if err := tx.Where("account_uuid = ?", accountUUID).
+		Order("stream_version").
+		Find(&eventsOrm).Error; err != nil {
+		return nil, fmt.Errorf("failed to get account stream: %w", err)
+	}
+
+	events := make([]account.Event, 0, len(eventsOrm))
+
+	for _, e := range eventsOrm {
+		var data account.EventData
+		if err := json.Unmarshal([]byte(e.Payload), &data); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal account event %v: %w", e.EventUUID, err)
+		}
+
+		events = append(events, account.Event{
+			EventUUID:     e.EventUUID,
+			AccountUUID:   e.AccountUUID,
+			StreamVersion: e.StreamVersion,
+			EventType:     e.EventType,
+			Data:          data,
+			Timestamp:     e.EventTimestamp,
+		})
+	}
+
+	return events, nil
+}
+
+// grava os eventos novos do aggregate e atualiza as projeções. A unique (account_uuid, stream_version)
+// garante a concorrência otimista: se outro writer gravou a mesma versão, o append falha
+func appendAccountEvents(tx *gorm.DB, acc *account.Account) error {
+	changes := acc.Changes()
+	if len(changes) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	eventsOrm := make([]BankAccountStreamEventOrm, 0, len(changes))
+
+	for _, e := range changes {
+		payload, err := json.Marshal(e.Data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal account event: %w", err)
+		}
+
+		eventsOrm = append(eventsOrm, BankAccountStreamEventOrm{
+			EventUUID:      e.EventUUID,
+			AccountUUID:    e.AccountUUID,
+			StreamVersion:  e.StreamVersion,
+			EventType:      e.EventType,
+			Payload:        string(payload),
+			EventTimestamp: e.Timestamp,
+			CreatedAt:      now,
+		})
+	}
+
+	if err := tx.Create(&eventsOrm).Error; err != nil {
+		// o pool é aberto com lib/pq, então o erro do driver é *pq.Error
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" && pqErr.Constraint == streamVersionConstraint {
+			return account.ErrStreamVersionConflict
+		}
+
+		return fmt.Errorf("failed to append account events: %w", err)
+	}
+
+	for _, e := range changes {
+		if err := projectAccountEvent(tx, e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+// canal usado pelo trigger bank_exchange_rates_notify
+const ExchangeRateChannel = "bank_exchange_rates"
+
+type exchangeRateNotification struct {
+	FromCurrency       string    `json:"from_currency"`
+	ToCurrency         string    `json:"to_currency"`
+	Rate               float64   `json:"rate"`
+	ValidFromTimestamp time.Time `json:"valid_from_timestamp"`
+	ValidToTimestamp   time.Time `json:"valid_to_timestamp"`
+}
+
+// escuta via LISTEN/NOTIFY as taxas inseridas por qualquer instância do servidor
+type ExchangeRateListener struct {
+	dsn string
+}
+
+func NewExchangeRateListener(dsn string) *ExchangeRateListener {
+	return &ExchangeRateListener{dsn: dsn}
+}
+
+func (l *ExchangeRateListener) Listen(ctx context.Context, handler func(bank.ExchangeRate)) error {
+	listener := pq.NewListener(l.dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.Warn("exchange rate listener connection event", "event", ev, "err", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(ExchangeRateChannel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case n := <-listener.Notify:
+			// notificação nil indica que a conexão foi restabelecida
+			if n == nil {
+				continue
+			}
+
+			var payload exchangeRateNotification
+			if err := json.Unmarshal([]byte(n.Extra), &payload); err != nil {
+				logger.Warn("invalid exchange rate notification", "payload", n.Extra, "err", err)
+				continue
+			}
+
+			handler(bank.ExchangeRate{
+				FromCurrency:       payload.FromCurrency,
+				ToCurrency:         payload.ToCurrency,
+				Rate:               payload.Rate,
+				ValidFromTimestamp: payload.ValidFromTimestamp,
+				ValidToTimestamp:   payload.ValidToTimestamp,
+			})
+		case <-time.After(90 * time.Second):
+			// ping periódico para detectar conexões mortas
+			go listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func newTransactionCreatedPayload(t BankTransactionOrm, balance float64) bank.TransactionCreatedPayload {
+	return bank.TransactionCreatedPayload{
+		TransactionUUID: t.TransactionUUID,
+		AccountUUID:     t.AccountUUID,
+		TransactionType: t.TransactionType,
+		Amount:          t.Amount,
+		Balance:         balance,
+		Notes:           t.Notes,
+		Timestamp:       t.TransactionTimestamp,
+	}
+}
+
+// chave do advisory lock que garante um único relay publicando entre as instâncias
+const outboxRelayLockKey int64 = 0x6f7574626f78
+
+// grava o evento no outbox usando a transação da operação que o originou
+func createOutboxEvent(tx *gorm.DB, aggregateType string, aggregateUUID uuid.UUID, eventType string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %v payload: %w", eventType, err)
+	}
+
+	// a versão é reservada antes do insert para que a outbox_sequence também saia depois do lock
+	version, err := nextOutboxAggregateVersion(tx, aggregateType, aggregateUUID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	return tx.Create(&BankOutboxOrm{
+		EventUUID:        uuid.New(),
+		AggregateType:    aggregateType,
+		AggregateUUID:    aggregateUUID,
+		AggregateVersion: version,
+		EventType:        eventType,
+		Payload:          string(data),
+		NextAttemptAt:    now,
+		CreatedAt:        now,
+	}).Error
+}
+
+// o upsert segura o lock da linha do agregado até o commit: o evento seguinte do mesmo agregado
+// só é gravado depois que este ficar visível, então o relay nunca publica a versão N+1 antes da N
+func nextOutboxAggregateVersion(tx *gorm.DB, aggregateType string, aggregateUUID uuid.UUID) (int64, error) {
+	var version int64
+
+	if err := tx.Raw(`
+		INSERT INTO bank_outbox_aggregates (aggregate_type, aggregate_uuid, last_version)
+		VALUES (?, ?, 1)
+		ON CONFLICT (aggregate_type, aggregate_uuid)
+		DO UPDATE SET last_version = bank_outbox_aggregates.last_version + 1
+		RETURNING last_version`, aggregateType, aggregateUUID).Scan(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to assign outbox aggregate version: %w", err)
+	}
+
+	return version, nil
+}
+
+// grava a transferência como falha e registra o evento no outbox para notificar os interessados.
+// A linha é criada se ainda não existir: regras de negócio rejeitam a transferência antes do CreateTransfer
+// e um lote desfeito não deixa nenhuma linha. Conta desconhecida fica NULL em vez de violar a foreign key
+func (a *DatabaseAdapter) MarkTransferFailed(ctx context.Context, transferOrm BankTransferOrm, reason string) error {
+	transferOrm.TransferSuccess = false
+	transferOrm.UpdatedAt = time.Now()
+
+	omit := make([]string, 0, 2)
+	if transferOrm.FromAccountUUID == uuid.Nil {
+		omit = append(omit, "FromAccountUUID")
+	}
+
+	if transferOrm.ToAccountUUID == uuid.Nil {
+		omit = append(omit, "ToAccountUUID")
+	}
+
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "transfer_uuid"}},
+			DoUpdates: clause.AssignmentColumns([]string{"transfer_success", "updated_at"}),
+		}).Omit(omit...).Create(&transferOrm).Error; err != nil {
+			return fmt.Errorf("failed to record failed transfer: %w", err)
+		}
+
+		return createOutboxEvent(tx, bank.AggregateTypeTransfer, transferOrm.TransferUUID, bank.EventTypeTransferFailed,
+			bank.TransferFailedPayload{
+				TransferUUID:    transferOrm.TransferUUID,
+				FromAccountUUID: transferOrm.FromAccountUUID,
+				ToAccountUUID:   transferOrm.ToAccountUUID,
+				Currency:        transferOrm.Currency,
+				Amount:          transferOrm.Amount,
+				Reason:          reason,
+				Timestamp:       transferOrm.TransferTimestamp,
+			})
+	})
+}
+
+// executa fn segurando um advisory lock de sessão. O lock fica na conexão reservada e é liberado
+// ao final ou quando a conexão cai; as demais consultas do relay usam o pool normalmente
+func (a *DatabaseAdapter) WithOutboxRelayLock(ctx context.Context, fn func() error) (bool, error) {
+	acquired := false
+
+	err := a.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Raw("SELECT pg_try_advisory_lock(?)", outboxRelayLockKey).Scan(&acquired).Error; err != nil {
+			return fmt.Errorf("failed to acquire outbox relay lock: %w", err)
+		}
+
+		if !acquired {
+			return nil
+		}
+
+		defer func() {
+			// contexto próprio: o lock precisa ser liberado mesmo com ctx cancelado
+			if err := conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", outboxRelayLockKey).Error; err != nil {
+				logger.ErrorContext(ctx, "failed to release outbox relay lock", "err", err)
+			}
+		}()
+
+		return fn()
+	})
+
+	return acquired, err
+}
+
+func (a *DatabaseAdapter) GetPendingOutboxEvents(limit int) ([]BankOutboxOrm, error) {
+	var eventsOrm []BankOutboxOrm
+
+	if err := a.db.Where("published_at IS NULL").
+		Order("outbox_sequence").
+		Limit(limit).
+		Find(&eventsOrm).Error; err != nil {
+		return nil, fmt.Errorf("failed to get pending outbox events: %w", err)
+	}
+
+	return eventsOrm, nil
+}
+
+func (a *DatabaseAdapter) MarkOutboxEventPublished(outboxSequence int64) error {
+	if err := a.db.Model(&BankOutboxOrm{}).
+		Where("outbox_sequence = ?", outboxSequence).
+		Updates(map[string]interface{}{
+			"published_at": time.Now(),
+			"last_error":   "",
+		}).Error; err != nil {
+		return fmt.Errorf("failed to mark outbox event published: %w", err)
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) MarkOutboxEventFailed(outboxSequence int64, lastError string, nextAttemptAt time.Time) error {
+	if err := a.db.Model(&BankOutboxOrm{}).
+		Where("outbox_sequence = ?", outboxSequence).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+
+	return nil
+}
+
+// copia o evento para a tabela de dead letter e o retira da fila, liberando os eventos seguintes
+func (a *DatabaseAdapter) MoveOutboxEventToDeadLetter(event BankOutboxOrm, lastError string) error {
+	now := time.Now()
+
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&BankOutboxDeadLetterOrm{
+			EventUUID:        event.EventUUID,
+			OutboxSequence:   event.OutboxSequence,
+			AggregateType:    event.AggregateType,
+			AggregateUUID:    event.AggregateUUID,
+			AggregateVersion: event.AggregateVersion,
+			EventType:        event.EventType,
+			Payload:          event.Payload,
+			Attempts:         event.Attempts + 1,
+			LastError:        lastError,
+			FailedAt:         now,
+			CreatedAt:        now,
+		}).Error; err != nil {
+			return fmt.Errorf("failed to create outbox dead letter: %w", err)
+		}
+
+		if err := tx.Delete(&BankOutboxOrm{}, "outbox_sequence = ?", event.OutboxSequence).Error; err != nil {
+			return fmt.Errorf("failed to remove outbox event: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/account"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"gorm.io/gorm"
+)
+
+// grava um lote de transações importadas e ajusta o saldo de cada conta pela soma do lote,
+// tudo na mesma transação. Importações históricas não geram eventos de conta nem outbox
+func (a *DatabaseAdapter) CreateTransactionsBulk(ctx context.Context, transactionsOrm []BankTransactionOrm) error {
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if a.copyIn {
+			if err := copyTransactions(tx, transactionsOrm); err != nil {
+				return err
+			}
+		} else if err := tx.CreateInBatches(&transactionsOrm, 500).Error; err != nil {
+			return fmt.Errorf("failed to insert transactions: %w", err)
+		}
+
+		deltas := make(map[uuid.UUID]float64)
+		for _, t := range transactionsOrm {
+			if t.TransactionType == bank.TransactionTypeOut {
+				deltas[t.AccountUUID] -= t.Amount
+			} else {
+				deltas[t.AccountUUID] += t.Amount
+			}
+		}
+
+		now := time.Now()
+		for accountUUID, delta := range deltas {
+			if err := tx.Model(&BankAccountOrm{}).
+				Where("account_uuid = ?", accountUUID).
+				Updates(map[string]interface{}{
+					"current_balance": gorm.Expr("current_balance + ?", delta),
+					"updated_at":      now,
+				}).Error; err != nil {
+				return fmt.Errorf("failed to update account balance: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// no modo eventsourced cada transação vira um evento no stream da conta. As regras do
+// aggregate valem para a importação, então um saque sem saldo faz o lote inteiro falhar
+func (a *EventSourcedDatabaseAdapter) CreateTransactionsBulk(ctx context.Context, transactionsOrm []BankTransactionOrm) error {
+	return a.withStreamRetry(ctx, func(tx *gorm.DB) error {
+		accounts := make(map[uuid.UUID]*account.Account)
+		order := make([]uuid.UUID, 0)
+
+		for _, t := range transactionsOrm {
+			acc, ok := accounts[t.AccountUUID]
+			if !ok {
+				var err error
+				if acc, err = loadAccountStream(tx, t.AccountUUID); err != nil {
+					return err
+				}
+
+				accounts[t.AccountUUID] = acc
+				order = append(order, t.AccountUUID)
+			}
+
+			var err error
+			if t.TransactionType == bank.TransactionTypeOut {
+				err = acc.Withdraw(t.TransactionUUID, t.Amount, t.Notes, t.TransactionTimestamp)
+			} else {
+				err = acc.Deposit(t.TransactionUUID, t.Amount, t.Notes, t.TransactionTimestamp)
+			}
+			if err != nil {
+				return fmt.Errorf("transaction %v: %w", t.TransactionUUID, err)
+			}
+		}
+
+		for _, id := range order {
+			if err := appendAccountEvents(tx, accounts[id]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func copyTransactions(tx *gorm.DB, transactionsOrm []BankTransactionOrm) error {
+	stmt, err := tx.Statement.ConnPool.PrepareContext(tx.Statement.Context, pq.CopyIn("bank_transactions",
+		"transaction_uuid", "account_uuid", "transaction_timestamp", "amount", "transaction_type", "notes", "created_at", "updated_at"))
+	if err != nil {
+		return fmt.Errorf("failed to prepare transactions copy: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, t := range transactionsOrm {
+		if _, err := stmt.ExecContext(tx.Statement.Context, t.TransactionUUID, t.AccountUUID, t.TransactionTimestamp,
+			t.Amount, t.TransactionType, t.Notes, t.CreatedAt, t.UpdatedAt); err != nil {
+			return fmt.Errorf("failed to copy transaction %v: %w", t.TransactionUUID, err)
+		}
+	}
+
+	// Exec sem argumentos envia o buffer e finaliza o COPY
+	if _, err := stmt.ExecContext(tx.Statement.Context); err != nil {
+		return fmt.Errorf("failed to copy transactions: %w", err)
+	}
+
+	return nil
+}
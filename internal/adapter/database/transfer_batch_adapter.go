@@ -0,0 +1,125 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TransferBatchItemOrm struct {
+	Transfer        BankTransferOrm
+	FromTransaction BankTransactionOrm
+	ToTransaction   BankTransactionOrm
+	// uuid.Nil quando a transferência não usa cotação
+	QuoteUUID uuid.UUID
+}
+
+type transferPairWriter interface {
+	CreateTransferTransactionPair(ctx context.Context, transferOrm BankTransferOrm, fromAccountOrm BankAccountOrm, toAccountOrm BankAccountOrm,
+		fromTransactionOrm BankTransactionOrm, toTransactionOrm BankTransactionOrm, quoteUUID uuid.UUID) (bool, error)
+}
+
+func (a *DatabaseAdapter) CreateTransferBatch(ctx context.Context, items []TransferBatchItemOrm) error {
+	return createTransferBatch(a.db.WithContext(ctx), items, func(tx *gorm.DB) transferPairWriter {
+		return &DatabaseAdapter{db: tx}
+	})
+}
+
+func (a *EventSourcedDatabaseAdapter) CreateTransferBatch(ctx context.Context, items []TransferBatchItemOrm) error {
+	return createTransferBatch(a.db.WithContext(ctx), items, func(tx *gorm.DB) transferPairWriter {
+		return NewEventSourcedDatabaseAdapter(&DatabaseAdapter{db: tx})
+	})
+}
+
+// grava o lote inteiro em uma transação. O par de cada transferência é gravado pelo writer do
+// modo de armazenamento, que abre um savepoint dentro da transação do lote
+func createTransferBatch(db *gorm.DB, items []TransferBatchItemOrm, newWriter func(tx *gorm.DB) transferPairWriter) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := lockTransferBatchAccounts(tx, items); err != nil {
+			return err
+		}
+
+		writer := newWriter(tx)
+
+		for i, item := range items {
+			// saldos relidos, itens anteriores do lote podem ter alterado as mesmas contas
+			var fromAccountOrm, toAccountOrm BankAccountOrm
+
+			if err := tx.First(&fromAccountOrm, "account_uuid = ?", item.Transfer.FromAccountUUID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return &bank.TransferBatchError{Index: i, Err: bank.ErrTransferSourceAccountNotFound}
+				}
+
+				return fmt.Errorf("failed to get transfer source account: %w", err)
+			}
+
+			if err := tx.First(&toAccountOrm, "account_uuid = ?", item.Transfer.ToAccountUUID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return &bank.TransferBatchError{Index: i, Err: bank.ErrTransferDestinationAccountNotFound}
+				}
+
+				return fmt.Errorf("failed to get transfer destination account: %w", err)
+			}
+
+			if fromAccountOrm.CurrentBalance < item.FromTransaction.Amount {
+				return &bank.TransferBatchError{Index: i, Err: bank.ErrTransferTransactionPair}
+			}
+
+			if err := tx.Create(&item.Transfer).Error; err != nil {
+				return &bank.TransferBatchError{Index: i, Err: bank.ErrTransferRecordFailed}
+			}
+
+			// a cotação é consumida no savepoint do par: se qualquer item falhar ela continua disponível
+			if _, err := writer.CreateTransferTransactionPair(tx.Statement.Context, item.Transfer, fromAccountOrm, toAccountOrm,
+				item.FromTransaction, item.ToTransaction, item.QuoteUUID); err != nil {
+				if errors.Is(err, bank.ErrExchangeQuoteUsed) {
+					return &bank.TransferBatchError{Index: i, Err: bank.ErrExchangeQuoteUsed}
+				}
+
+				return &bank.TransferBatchError{Index: i, Err: bank.ErrTransferTransactionPair}
+			}
+		}
+
+		return nil
+	})
+}
+
+// trava todas as contas do lote antes do primeiro item, sempre em ordem de UUID: lotes que tocam
+// as mesmas contas em ordens diferentes esperam um pelo outro em vez de entrar em deadlock.
+// Contas inexistentes são ignoradas aqui e reportadas com o índice do item no loop do lote
+func lockTransferBatchAccounts(tx *gorm.DB, items []TransferBatchItemOrm) error {
+	seen := make(map[uuid.UUID]struct{}, 2*len(items))
+	accountUUIDs := make([]uuid.UUID, 0, 2*len(items))
+
+	for _, item := range items {
+		for _, id := range []uuid.UUID{item.Transfer.FromAccountUUID, item.Transfer.ToAccountUUID} {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				accountUUIDs = append(accountUUIDs, id)
+			}
+		}
+	}
+
+	sort.Slice(accountUUIDs, func(i, j int) bool {
+		return bytes.Compare(accountUUIDs[i][:], accountUUIDs[j][:]) < 0
+	})
+
+	for _, id := range accountUUIDs {
+		var accountOrm BankAccountOrm
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("account_uuid").
+			First(&accountOrm, "account_uuid = ?", id).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to lock transfer account %v: %w", id, err)
+		}
+	}
+
+	return nil
+}
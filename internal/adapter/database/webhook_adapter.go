@@ -0,0 +1,161 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/webhook"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (a *DatabaseAdapter) CreateWebhookSubscription(sub WebhookSubscriptionOrm) (uuid.UUID, error) {
+	if err := a.db.Create(&sub).Error; err != nil {
+		return uuid.Nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return sub.SubscriptionUUID, nil
+}
+
+// a inscrição é apenas desativada para manter o histórico de entregas
+func (a *DatabaseAdapter) DeactivateWebhookSubscription(subscriptionUUID uuid.UUID) error {
+	res := a.db.Model(&WebhookSubscriptionOrm{}).
+		Where("subscription_uuid = ? AND active", subscriptionUUID).
+		Updates(map[string]interface{}{
+			"active":     false,
+			"updated_at": time.Now(),
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to deactivate webhook subscription: %w", res.Error)
+	}
+
+	if res.RowsAffected == 0 {
+		return webhook.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) GetActiveWebhookSubscriptions(accountUUIDs []uuid.UUID, eventType string) ([]WebhookSubscriptionOrm, error) {
+	var subsOrm []WebhookSubscriptionOrm
+
+	if err := a.db.Where("active AND account_uuid IN ? AND event_type IN ?", accountUUIDs, []string{eventType, webhook.EventTypeAll}).
+		Find(&subsOrm).Error; err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscriptions: %w", err)
+	}
+
+	return subsOrm, nil
+}
+
+// uma entrega por inscrição e evento, eventos reentregues pelo outbox não geram duplicatas
+func (a *DatabaseAdapter) CreateWebhookDeliveries(deliveries []WebhookDeliveryOrm) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	if err := a.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Omit("Subscription").
+		Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func (a *DatabaseAdapter) GetDueWebhookDeliveries(ts time.Time, limit int) ([]WebhookDeliveryOrm, error) {
+	var deliveriesOrm []WebhookDeliveryOrm
+
+	if err := a.db.Preload("Subscription").
+		Where("status = ? AND next_attempt_at <= ?", webhook.DeliveryStatusPending, ts).
+		Order("next_attempt_at").
+		Limit(limit).
+		Find(&deliveriesOrm).Error; err != nil {
+		return nil, fmt.Errorf("failed to get due webhook deliveries: %w", err)
+	}
+
+	return deliveriesOrm, nil
+}
+
+// grava o log da tentativa e o novo estado da entrega na mesma transação
+func (a *DatabaseAdapter) RecordWebhookDeliveryAttempt(delivery WebhookDeliveryOrm, attempt WebhookDeliveryAttemptOrm) error {
+	return a.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&attempt).Error; err != nil {
+			return fmt.Errorf("failed to create webhook delivery attempt: %w", err)
+		}
+
+		if err := tx.Model(&WebhookDeliveryOrm{}).
+			Where("delivery_uuid = ?", delivery.DeliveryUUID).
+			Updates(map[string]interface{}{
+				"status":           delivery.Status,
+				"attempts":         delivery.Attempts,
+				"last_status_code": delivery.LastStatusCode,
+				"last_error":       delivery.LastError,
+				"next_attempt_at":  delivery.NextAttemptAt,
+				"delivered_at":     delivery.DeliveredAt,
+				"updated_at":       time.Now(),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to update webhook delivery: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// entregas das inscrições da conta, das mais recentes para as mais antigas
+func (a *DatabaseAdapter) ListWebhookDeliveries(accountUUID uuid.UUID, status string,
+	beforeCreatedAt time.Time, beforeUUID uuid.UUID, limit int) ([]WebhookDeliveryOrm, error) {
+	var deliveriesOrm []WebhookDeliveryOrm
+
+	query := a.db.Joins("JOIN bank_webhook_subscriptions s ON s.subscription_uuid = bank_webhook_deliveries.subscription_uuid").
+		Where("s.account_uuid = ?", accountUUID)
+
+	if status != "" {
+		query = query.Where("bank_webhook_deliveries.status = ?", status)
+	}
+
+	if beforeUUID != uuid.Nil {
+		query = query.Where("(bank_webhook_deliveries.created_at, bank_webhook_deliveries.delivery_uuid) < (?, ?)", beforeCreatedAt, beforeUUID)
+	}
+
+	if err := query.Order("bank_webhook_deliveries.created_at DESC, bank_webhook_deliveries.delivery_uuid DESC").
+		Limit(limit).
+		Find(&deliveriesOrm).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveriesOrm, nil
+}
+
+func (a *DatabaseAdapter) GetWebhookDelivery(deliveryUUID uuid.UUID) (WebhookDeliveryOrm, error) {
+	var deliveryOrm WebhookDeliveryOrm
+
+	if err := a.db.First(&deliveryOrm, "delivery_uuid = ?", deliveryUUID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return deliveryOrm, webhook.ErrDeliveryNotFound
+		}
+
+		return deliveryOrm, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	return deliveryOrm, nil
+}
+
+// volta a entrega para a fila com o orçamento de tentativas zerado, mantendo os logs anteriores
+func (a *DatabaseAdapter) ResetWebhookDelivery(deliveryUUID uuid.UUID, ts time.Time) error {
+	if err := a.db.Model(&WebhookDeliveryOrm{}).
+		Where("delivery_uuid = ?", deliveryUUID).
+		Updates(map[string]interface{}{
+			"status":          webhook.DeliveryStatusPending,
+			"attempts":        0,
+			"last_error":      "",
+			"next_attempt_at": ts,
+			"delivered_at":    nil,
+			"updated_at":      time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to reset webhook delivery: %w", err)
+	}
+
+	return nil
+}
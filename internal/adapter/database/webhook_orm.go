@@ -0,0 +1,57 @@
+package database
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookSubscriptionOrm struct {
+	SubscriptionUUID uuid.UUID `gorm:"primaryKey"`
+	AccountUUID      uuid.UUID
+	EventType        string
+	URL              string `gorm:"column:url"`
+	Secret           string
+	Active           bool
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+func (WebhookSubscriptionOrm) TableName() string {
+	return "bank_webhook_subscriptions"
+}
+
+type WebhookDeliveryOrm struct {
+	DeliveryUUID     uuid.UUID `gorm:"primaryKey"`
+	SubscriptionUUID uuid.UUID
+	EventUUID        uuid.UUID
+	EventType        string
+	Payload          string `gorm:"type:jsonb"`
+	Status           string
+	Attempts         int
+	LastStatusCode   int
+	LastError        string
+	NextAttemptAt    time.Time
+	DeliveredAt      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Subscription     WebhookSubscriptionOrm `gorm:"foreignKey:SubscriptionUUID;references:SubscriptionUUID"`
+}
+
+func (WebhookDeliveryOrm) TableName() string {
+	return "bank_webhook_deliveries"
+}
+
+type WebhookDeliveryAttemptOrm struct {
+	AttemptUUID   uuid.UUID `gorm:"primaryKey"`
+	DeliveryUUID  uuid.UUID
+	AttemptNumber int
+	StatusCode    int
+	Error         string
+	DurationMs    int64
+	AttemptedAt   time.Time
+}
+
+func (WebhookDeliveryAttemptOrm) TableName() string {
+	return "bank_webhook_delivery_attempts"
+}
@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	domainBank "github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// verifica se o chamador autenticado pode executar a ação na conta. Com a autenticação desligada tudo é permitido.
+// Nas streams deve ser chamado a cada mensagem, já que cada uma pode trazer outra conta
+func (a *GrpcAdapter) authorize(ctx context.Context, action auth.Action, accountNumber string) error {
+	if !a.cfg.Auth.Enabled {
+		return nil
+	}
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if err := principal.Authorize(action, accountNumber); err != nil {
+		logger.WarnContext(ctx, "authorization denied", "subject", principal.Subject, "action", action,
+			"account_number", accountNumber, "err", err)
+		return buildPermissionDeniedStatusGrpc(principal, action, accountNumber)
+	}
+
+	return nil
+}
+
+// tier de preço das cotações e streams de taxa. O tier do request só é aceito de teller e admin, que cotam
+// em nome de um cliente; os demais chamadores recebem o tier do próprio token, ou o default
+func (a *GrpcAdapter) customerTier(ctx context.Context, requested string) string {
+	if !a.cfg.Auth.Enabled {
+		return requested
+	}
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return domainBank.CustomerTierDefault
+	}
+
+	if requested != "" && (principal.HasRole(auth.RoleTeller) || principal.HasRole(auth.RoleAdmin)) {
+		return requested
+	}
+
+	if principal.Tier != "" {
+		return principal.Tier
+	}
+
+	return domainBank.CustomerTierDefault
+}
+
+func buildPermissionDeniedStatusGrpc(principal auth.Principal, action auth.Action, accountNumber string) error {
+	reason := "ACTION_NOT_ALLOWED"
+	description := "caller is not allowed to " + string(action)
+	if accountNumber != "" {
+		reason = "ACCOUNT_ACCESS_DENIED"
+		description += " on account " + accountNumber
+	}
+
+	s := status.New(codes.PermissionDenied, description)
+	s, _ = s.WithDetails(&errdetails.ErrorInfo{
+		Domain: "bank.com",
+		Reason: reason,
+		Metadata: map[string]string{
+			"subject":        principal.Subject,
+			"roles":          strings.Join(principal.Roles, ","),
+			"action":         string(action),
+			"account_number": accountNumber,
+		},
+	})
+
+	return s.Err()
+}
@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	domainBank "github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-proto/protogen/go/bank"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	accountEventsBatchSize = 100
+	// eventos gravados por outras instâncias não passam pelo hub local, então a stream
+	// também consulta o banco periodicamente
+	accountEventsPollInterval = 30 * time.Second
+)
+
+func (a *GrpcAdapter) StreamAccountEvents(req *bank.AccountEventsRequest, stream bank.BankService_StreamAccountEventsServer) error {
+	ctx := stream.Context()
+
+	if err := a.authorize(ctx, auth.ActionViewAccount, req.AccountNumber); err != nil {
+		return err
+	}
+
+	// inscreve antes da primeira leitura para não perder eventos gravados entre a leitura e a inscrição
+	notify, unsubscribe := a.accountEventHub.Subscribe(req.AccountNumber)
+	defer unsubscribe()
+
+	ticker := time.NewTicker(accountEventsPollInterval)
+	defer ticker.Stop()
+
+	// o campo sequence do proto carrega a versão do evento dentro da conta
+	lastVersion := req.FromSequence
+
+	for {
+		events, err := a.bankService.FindAccountEvents(ctx, req.AccountNumber, lastVersion, accountEventsBatchSize)
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to get account events", "account_number", req.AccountNumber, "err", err)
+			return status.Error(codes.FailedPrecondition, "failed to get account events")
+		}
+
+		for _, e := range events {
+			err := stream.Send(&bank.AccountEvent{
+				Sequence:      e.Version,
+				AccountNumber: e.AccountNumber,
+				TransactionId: e.TransactionUUID.String(),
+				Type:          toTransactionTypeGrpc(e.TransactionType),
+				Amount:        e.Amount,
+				Balance:       e.Balance,
+				Notes:         e.Notes,
+				Timestamp:     toDatetime(e.Timestamp),
+			})
+			if err != nil {
+				logger.WarnContext(ctx, "failed to send account event", "err", err)
+				return err
+			}
+
+			lastVersion = e.Version
+		}
+
+		// lote cheio, ainda podem existir eventos pendentes
+		if len(events) == accountEventsBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "client cancelled the stream")
+			return nil
+		case <-notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+func toTransactionTypeGrpc(transactionType string) bank.TransactionType {
+	var res bank.TransactionType
+
+	switch transactionType {
+	case domainBank.TransactionTypeIn:
+		res = bank.TransactionType_TRANSACTION_TYPE_IN
+	case domainBank.TransactionTypeOut:
+		res = bank.TransactionType_TRANSACTION_TYPE_OUT
+	}
+
+	return res
+}
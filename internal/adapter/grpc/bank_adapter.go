@@ -5,14 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"strconv"
 	"time"
 
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
-	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
 	domainBank "github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
 	"github.com/viquitorreis/my-grpc-proto/protogen/go/bank"
 	"google.golang.org/genproto/googleapis/type/date"
@@ -20,10 +21,14 @@ import (
 )
 
 func (a *GrpcAdapter) GetCurrentBalance(ctx context.Context, req *bank.CurrentBalanceRequest) (*bank.CurrentBalanceResponse, error) {
+	if err := a.authorize(ctx, auth.ActionViewAccount, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
-	bal, err := a.bankService.FindCurrentBalance(req.AccountNumber)
+	bal, err := a.bankService.FindCurrentBalance(ctx, req.AccountNumber)
 	if err != nil {
-		log.Printf("failed to get current balance: %v\n", err)
+		logger.ErrorContext(ctx, "failed to get current balance", "account_number", req.AccountNumber, "err", err)
 		return nil, status.Error(codes.FailedPrecondition, "failed to get current balance")
 	}
 
@@ -38,82 +43,247 @@ func (a *GrpcAdapter) GetCurrentBalance(ctx context.Context, req *bank.CurrentBa
 }
 
 func (a *GrpcAdapter) FetchExchangeRates(req *bank.ExchangeRateRequest, stream bank.BankService_FetchExchangeRatesServer) error {
-	context := stream.Context()
+	ctx := stream.Context()
+
+	// as taxas chegam pelo hub quando são gravadas, sem consultar o banco a cada stream
+	sub, err := a.exchangeRateHub.Subscribe(ctx, req.FromCurrency, req.ToCurrency, a.customerTier(ctx, req.CustomerTier))
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get exchange rate", "from_currency", req.FromCurrency, "to_currency", req.ToCurrency, "err", err)
+		s := status.New(codes.FailedPrecondition, "failed to get exchange rate")
+		s, _ = s.WithDetails(&errdetails.ErrorInfo{
+			Domain: "bank.com",
+			Reason: "failed to get exchange rate",
+			Metadata: map[string]string{
+				"from_currency": req.FromCurrency,
+				"to_currency":   req.ToCurrency,
+			},
+		})
+
+		return s.Err()
+	}
+	defer sub.Close()
 
 	for {
 		select {
-		case <-context.Done():
-			log.Println("client cancelou o streaming")
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "client cancelled the stream")
 			return nil
-		default:
-			now := time.Now().Truncate(time.Second)
-			rate, err := a.bankService.GetExchangeRate(req.FromCurrency, req.ToCurrency, now)
-			if err != nil {
-				log.Printf("failed to get exchange rate: %v\n", err)
-				s := status.New(codes.FailedPrecondition, "failed to get exchange rate")
-				s, _ = s.WithDetails(&errdetails.ErrorInfo{
-					Domain: "bank.com",
-					Reason: "failed to get exchange rate",
-					Metadata: map[string]string{
-						"from_currency": req.FromCurrency,
-						"to_currency":   req.ToCurrency,
-					},
-				})
-
-				return s.Err()
-			}
+		case <-sub.Done():
+			logger.WarnContext(ctx, "exchange rate subscription closed", "err", sub.Err())
+			s := status.New(codes.ResourceExhausted, "exchange rate stream closed")
+			s, _ = s.WithDetails(&errdetails.ErrorInfo{
+				Domain: "bank.com",
+				Reason: "SLOW_CONSUMER",
+				Metadata: map[string]string{
+					"from_currency": req.FromCurrency,
+					"to_currency":   req.ToCurrency,
+				},
+			})
 
-			stream.Send(&bank.ExchangeRateResponse{
+			return s.Err()
+		case price := <-sub.Updates():
+			err := stream.Send(&bank.ExchangeRateResponse{
 				FromCurrency: req.FromCurrency,
 				ToCurrency:   req.ToCurrency,
-				Rate:         rate,
-				Timestamp:    now.Format(time.RFC3339),
+				Rate:         price.MidRate,
+				BuyRate:      price.BuyRate,
+				SellRate:     price.SellRate,
+				Timestamp:    time.Now().Truncate(time.Second).Format(time.RFC3339),
 			})
+			if err != nil {
+				logger.WarnContext(ctx, "failed to send exchange rate", "err", err)
+				return err
+			}
 
-			log.Printf("Exchange rate send to client, %v to %v: %v\n", req.FromCurrency, req.ToCurrency, rate)
-
-			time.Sleep(3 * time.Second)
+			logger.DebugContext(ctx, "exchange rate sent to client", "from_currency", req.FromCurrency, "to_currency", req.ToCurrency,
+				"rate", price.MidRate, "source", price.Source)
 		}
 	}
 }
 
+func (a *GrpcAdapter) CreateExchangeQuote(ctx context.Context, req *bank.ExchangeQuoteRequest) (*bank.ExchangeQuoteResponse, error) {
+	quote, err := a.bankService.CreateExchangeQuote(ctx, req.FromCurrency, req.ToCurrency, a.customerTier(ctx, req.CustomerTier))
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to create exchange quote", "err", err)
+		s := status.New(codes.FailedPrecondition, "failed to create exchange quote")
+		s, _ = s.WithDetails(&errdetails.ErrorInfo{
+			Domain: "bank.com",
+			Reason: "failed to create exchange quote",
+			Metadata: map[string]string{
+				"from_currency": req.FromCurrency,
+				"to_currency":   req.ToCurrency,
+			},
+		})
+
+		return nil, s.Err()
+	}
+
+	return &bank.ExchangeQuoteResponse{
+		QuoteId:         quote.QuoteUUID.String(),
+		FromCurrency:    quote.FromCurrency,
+		ToCurrency:      quote.ToCurrency,
+		Rate:            quote.Rate,
+		Spread:          quote.Spread,
+		ExpiryTimestamp: toDatetime(quote.ExpiresAt),
+	}, nil
+}
+
+func (a *GrpcAdapter) GetExchangeRateHistory(ctx context.Context, req *bank.ExchangeRateHistoryRequest) (*bank.ExchangeRateHistoryResponse, error) {
+	if req.StartTimestamp == nil || req.EndTimestamp == nil {
+		return nil, buildExchangeRateHistoryErrorStatusGrpc(domainBank.ErrInvalidTimeRange)
+	}
+
+	start, err := toTime(req.StartTimestamp)
+	if err != nil {
+		return nil, buildExchangeRateHistoryErrorStatusGrpc(domainBank.ErrInvalidTimeRange)
+	}
+
+	end, err := toTime(req.EndTimestamp)
+	if err != nil {
+		return nil, buildExchangeRateHistoryErrorStatusGrpc(domainBank.ErrInvalidTimeRange)
+	}
+
+	rates, nextPageToken, err := a.bankService.GetExchangeRateHistory(ctx, req.FromCurrency, req.ToCurrency, start, end,
+		int(req.PageSize), req.PageToken)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get exchange rate history", "err", err)
+		return nil, buildExchangeRateHistoryErrorStatusGrpc(err)
+	}
+
+	res := &bank.ExchangeRateHistoryResponse{
+		Rates:         make([]*bank.ExchangeRateHistoryEntry, 0, len(rates)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, r := range rates {
+		res.Rates = append(res.Rates, &bank.ExchangeRateHistoryEntry{
+			Rate:               r.Rate,
+			ValidFromTimestamp: toDatetime(r.ValidFromTimestamp),
+			ValidToTimestamp:   toDatetime(r.ValidToTimestamp),
+		})
+	}
+
+	return res, nil
+}
+
+func (a *GrpcAdapter) GetExchangeRateCandles(ctx context.Context, req *bank.ExchangeRateCandlesRequest) (*bank.ExchangeRateCandlesResponse, error) {
+	if req.StartTimestamp == nil || req.EndTimestamp == nil {
+		return nil, buildExchangeRateHistoryErrorStatusGrpc(domainBank.ErrInvalidTimeRange)
+	}
+
+	start, err := toTime(req.StartTimestamp)
+	if err != nil {
+		return nil, buildExchangeRateHistoryErrorStatusGrpc(domainBank.ErrInvalidTimeRange)
+	}
+
+	end, err := toTime(req.EndTimestamp)
+	if err != nil {
+		return nil, buildExchangeRateHistoryErrorStatusGrpc(domainBank.ErrInvalidTimeRange)
+	}
+
+	interval := ""
+
+	switch req.Interval {
+	case bank.CandleInterval_CANDLE_INTERVAL_1M:
+		interval = domainBank.CandleIntervalMinute
+	case bank.CandleInterval_CANDLE_INTERVAL_1H:
+		interval = domainBank.CandleIntervalHour
+	case bank.CandleInterval_CANDLE_INTERVAL_1D:
+		interval = domainBank.CandleIntervalDay
+	}
+
+	candles, nextPageToken, err := a.bankService.GetExchangeRateCandles(ctx, req.FromCurrency, req.ToCurrency, interval, start, end,
+		int(req.PageSize), req.PageToken)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get exchange rate candles", "err", err)
+		return nil, buildExchangeRateHistoryErrorStatusGrpc(err)
+	}
+
+	res := &bank.ExchangeRateCandlesResponse{
+		Candles:       make([]*bank.ExchangeRateCandle, 0, len(candles)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, c := range candles {
+		res.Candles = append(res.Candles, &bank.ExchangeRateCandle{
+			OpenTimestamp: toDatetime(c.OpenTimestamp),
+			Open:          c.Open,
+			High:          c.High,
+			Low:           c.Low,
+			Close:         c.Close,
+			Samples:       c.Samples,
+		})
+	}
+
+	return res, nil
+}
+
+func buildExchangeRateHistoryErrorStatusGrpc(err error) error {
+	field := ""
+
+	switch {
+	case errors.Is(err, domainBank.ErrInvalidTimeRange):
+		field = "end_timestamp"
+	case errors.Is(err, domainBank.ErrInvalidCandleInterval):
+		field = "interval"
+	case errors.Is(err, domainBank.ErrInvalidPageToken):
+		field = "page_token"
+	default:
+		return status.Error(codes.Internal, "failed to query exchange rate history")
+	}
+
+	s := status.New(codes.InvalidArgument, err.Error())
+	s, _ = s.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{
+				Field:       field,
+				Description: err.Error(),
+			},
+		},
+	})
+
+	return s.Err()
+}
+
+// com o metadata "summarize-only: true" as transações são validadas e resumidas sem serem gravadas
+const summarizeOnlyMetadataKey = "summarize-only"
+
 func (a *GrpcAdapter) SummarizeTransactions(stream bank.BankService_SummarizeTransactionsServer) error {
-	tsum := domainBank.TransactionSummary{
-		SummaryOnDate: time.Now(),
-		SumIn:         0,
-		SumOut:        0,
-		SumTotal:      0,
+	summarizeOnly := false
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		if v := md.Get(summarizeOnlyMetadataKey); len(v) > 0 {
+			summarizeOnly, _ = strconv.ParseBool(v[0])
+		}
 	}
 
-	account := ""
+	// no modo summarize-only nada é gravado, então basta poder consultar a conta
+	action := auth.ActionPostTransaction
+	if summarizeOnly {
+		action = auth.ActionViewAccount
+	}
+
+	report := domainBank.TransactionSummaryReport{}
 
 	// loop infinito para receber as conexões do client
-	for {
+	for n := 1; ; n++ {
 		req, err := stream.Recv()
 
 		if err == io.EOF {
-			res := bank.TransactionSummary{
-				AccountNumber: account,
-				SumAmountIn:   tsum.SumIn,
-				SumAmountOut:  tsum.SumOut,
-				SumTotal:      tsum.SumTotal,
-				TransactionDate: &date.Date{
-					Year:  int32(tsum.SummaryOnDate.Year()),
-					Month: int32(tsum.SummaryOnDate.Month()),
-					Day:   int32(tsum.SummaryOnDate.Day()),
-				},
-			}
-
-			return stream.SendAndClose(&res)
+			return stream.SendAndClose(toTransactionSummaryGrpc(&report, summarizeOnly))
 		}
 
 		if err != nil {
-			log.Fatalf("failed to receive transaction from client: %v\n", err)
+			logger.WarnContext(stream.Context(), "failed to receive transaction from client", "err", err)
+			return err
+		}
+
+		if err := a.authorize(stream.Context(), action, req.AccountNumber); err != nil {
+			return err
 		}
 
 		ts, err := toTime(req.Timestamp)
 		if err != nil {
-			log.Fatalf("failed to convert timestamp: %v\n", err)
+			return buildTransactionSummaryErrorStatusGrpc(err, n, req)
 		}
 
 		tranType := domainBank.TransactionTypeUnknown
@@ -128,42 +298,97 @@ func (a *GrpcAdapter) SummarizeTransactions(stream bank.BankService_SummarizeTra
 			Amount:          req.Amount,
 			Timestamp:       ts,
 			TransactionType: tranType,
+			Notes:           req.Notes,
 		}
 
-		accUUID, err := a.bankService.CreateTransaction(req.AccountNumber, tcurrent)
-		if err != nil && accUUID == uuid.Nil {
-			s := status.New(codes.InvalidArgument, err.Error())
-			s, _ = s.WithDetails(&errdetails.BadRequest{
-				FieldViolations: []*errdetails.BadRequest_FieldViolation{
-					{
-						Field:       "account_number",
-						Description: "invalid account number",
-					},
-				},
-			})
+		if err := a.bankService.SummarizeTransaction(stream.Context(), &report, req.AccountNumber, tcurrent, summarizeOnly); err != nil {
+			logger.WarnContext(stream.Context(), "failed to summarize transaction", "transaction", n, "err", err)
+			return buildTransactionSummaryErrorStatusGrpc(err, n, req)
+		}
+	}
+}
 
-			return s.Err()
-		} else if err != nil && accUUID != uuid.Nil {
-			s := status.New(codes.InvalidArgument, err.Error())
-			s, _ = s.WithDetails(&errdetails.BadRequest{
-				FieldViolations: []*errdetails.BadRequest_FieldViolation{
-					{
-						Field:       "amount",
-						Description: fmt.Sprintf("invalid amount: %v. Exceeds available balance", req.Amount),
-					},
-				},
-			})
+func toTransactionSummaryGrpc(report *domainBank.TransactionSummaryReport, summarizeOnly bool) *bank.TransactionSummary {
+	res := &bank.TransactionSummary{
+		SumAmountIn:      report.Summary.SumIn,
+		SumAmountOut:     report.Summary.SumOut,
+		SumTotal:         report.Summary.SumTotal,
+		TransactionCount: int64(report.Summary.TransactionCount),
+		SummarizeOnly:    summarizeOnly,
+		TransactionDate:  toDate(time.Now()),
+		Accounts:         make([]*bank.AccountTransactionSummary, 0, len(report.Accounts)),
+	}
 
-			return s.Err()
+	// campos de conta única mantidos para clients que enviam transações de uma conta só
+	if len(report.Accounts) == 1 {
+		res.AccountNumber = report.Accounts[0].AccountNumber
+	}
+
+	if report.Summary.TransactionCount > 0 {
+		res.TransactionDate = toDate(report.Summary.SummaryOnDate)
+	}
+
+	for _, acc := range report.Accounts {
+		accRes := &bank.AccountTransactionSummary{
+			AccountNumber:    acc.AccountNumber,
+			SumAmountIn:      acc.Summary.SumIn,
+			SumAmountOut:     acc.Summary.SumOut,
+			SumTotal:         acc.Summary.SumTotal,
+			TransactionCount: int64(acc.Summary.TransactionCount),
+			Days:             make([]*bank.DailyTransactionSummary, 0, len(acc.Days)),
 		}
 
-		// chamando camada service para calular o resumo da transação
-		err = a.bankService.CalculateTransactionSummary(&tsum, tcurrent)
-		if err != nil {
-			log.Printf("failed to calculate transaction summary: %v\n", err)
-			return err
+		for _, d := range acc.Days {
+			accRes.Days = append(accRes.Days, &bank.DailyTransactionSummary{
+				TransactionDate:  toDate(d.SummaryOnDate),
+				SumAmountIn:      d.SumIn,
+				SumAmountOut:     d.SumOut,
+				SumTotal:         d.SumTotal,
+				TransactionCount: int64(d.TransactionCount),
+			})
 		}
+
+		res.Accounts = append(res.Accounts, accRes)
+	}
+
+	return res
+}
+
+func buildTransactionSummaryErrorStatusGrpc(err error, n int, req *bank.Transaction) error {
+	field := ""
+	description := ""
+
+	switch {
+	case errors.Is(err, errInvalidDatetime):
+		field = "timestamp"
+		description = err.Error()
+	case errors.Is(err, domainBank.ErrTransactionAccountNotFound):
+		field = "account_number"
+		description = "invalid account number"
+	case errors.Is(err, domainBank.ErrTransactionInvalidType):
+		field = "type"
+		description = err.Error()
+	case errors.Is(err, domainBank.ErrTransactionInvalidAmount):
+		field = "amount"
+		description = fmt.Sprintf("invalid amount: %v. Must be positive", req.Amount)
+	case errors.Is(err, domainBank.ErrTransactionInsufficientFunds):
+		field = "amount"
+		description = fmt.Sprintf("invalid amount: %v. Exceeds available balance", req.Amount)
+	default:
+		return status.Errorf(codes.Internal, "failed to create transaction %d", n)
 	}
+
+	s := status.New(codes.InvalidArgument, fmt.Sprintf("transaction %d: %v", n, err))
+	s, _ = s.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{
+				Field:       field,
+				Description: fmt.Sprintf("transaction %d: %v", n, description),
+			},
+		},
+	})
+
+	return s.Err()
 }
 
 func currentDatetime() *datetime.DateTime {
@@ -181,45 +406,106 @@ func currentDatetime() *datetime.DateTime {
 	}
 }
 
+func toDatetime(t time.Time) *datetime.DateTime {
+	t = t.UTC()
+
+	return &datetime.DateTime{
+		Year:       int32(t.Year()),
+		Month:      int32(t.Month()),
+		Day:        int32(t.Day()),
+		Hours:      int32(t.Hour()),
+		Minutes:    int32(t.Minute()),
+		Seconds:    int32(t.Second()),
+		Nanos:      int32(t.Nanosecond()),
+		TimeOffset: &datetime.DateTime_UtcOffset{},
+	}
+}
+
+var errInvalidDatetime = errors.New("invalid datetime")
+
+// converte respeitando o fuso do DateTime (time_zone ou utc_offset), sem fuso é UTC
 func toTime(dt *datetime.DateTime) (time.Time, error) {
 	if dt == nil {
-		now := time.Now()
-
-		dt = &datetime.DateTime{
-			Year:    int32(now.Year()),
-			Month:   int32(now.Month()),
-			Day:     int32(now.Day()),
-			Hours:   int32(now.Hour()),
-			Minutes: int32(now.Minute()),
-			Seconds: int32(now.Second()),
-			Nanos:   int32(now.Nanosecond()),
+		return time.Now().UTC(), nil
+	}
+
+	if dt.Year < 1 || dt.Month < 1 || dt.Month > 12 || dt.Day < 1 || dt.Day > 31 ||
+		dt.Hours < 0 || dt.Hours > 23 || dt.Minutes < 0 || dt.Minutes > 59 ||
+		dt.Seconds < 0 || dt.Seconds > 59 || dt.Nanos < 0 || dt.Nanos > 999999999 {
+		return time.Time{}, fmt.Errorf("%w: out of range fields", errInvalidDatetime)
+	}
+
+	loc := time.UTC
+
+	if tz := dt.GetTimeZone(); tz != nil {
+		l, err := time.LoadLocation(tz.GetId())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: unknown time zone %q", errInvalidDatetime, tz.GetId())
 		}
+
+		loc = l
+	} else if offset := dt.GetUtcOffset(); offset != nil {
+		loc = time.FixedZone("", int(offset.AsDuration().Seconds()))
 	}
 
 	res := time.Date(
 		int(dt.Year), time.Month(dt.Month), int(dt.Day),
-		int(dt.Hours), int(dt.Minutes), int(dt.Seconds), int(dt.Nanos), time.UTC,
+		int(dt.Hours), int(dt.Minutes), int(dt.Seconds), int(dt.Nanos), loc,
 	)
 
+	// time.Date normaliza datas como 30 de fevereiro para o mês seguinte
+	if res.Day() != int(dt.Day) {
+		return time.Time{}, fmt.Errorf("%w: %04d-%02d-%02d is not a valid date", errInvalidDatetime, dt.Year, dt.Month, dt.Day)
+	}
+
 	return res, nil
 }
 
+func toDate(t time.Time) *date.Date {
+	return &date.Date{
+		Year:  int32(t.Year()),
+		Month: int32(t.Month()),
+		Day:   int32(t.Day()),
+	}
+}
+
 func (a *GrpcAdapter) TransferMultiple(stream bank.BankService_TransferMultipleServer) error {
-	context := stream.Context()
+	ctx := stream.Context()
+
+	// requests com batch_id são acumulados até o item com batch_commit e gravados juntos
+	var batch *transferBatch
 
 	for {
 		select {
-		case <-context.Done():
-			log.Println("client cancelou o streaming")
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "client cancelled the stream")
 			return nil
 		default:
 			req, err := stream.Recv()
 			if err == io.EOF {
+				if batch != nil {
+					return buildTransferBatchErrorStatusGrpc(domainBank.ErrTransferBatchNotCommitted, batch.id)
+				}
+
 				return nil
 			}
 
+			// só erros de transporte encerram a stream, falhas de negócio voltam no TransferResponse
 			if err != nil {
-				log.Printf("failed to receive transaction from client: %v\n", err)
+				logger.WarnContext(ctx, "failed to receive transaction from client", "err", err)
+				return err
+			}
+
+			if err := a.authorize(ctx, auth.ActionTransfer, req.FromAccountNumber); err != nil {
+				return err
+			}
+
+			if req.BatchId != "" || batch != nil {
+				if batch, err = a.addToTransferBatch(stream, batch, req); err != nil {
+					return err
+				}
+
+				continue
 			}
 
 			tt := domainBank.TransferTransaction{
@@ -227,12 +513,12 @@ func (a *GrpcAdapter) TransferMultiple(stream bank.BankService_TransferMultipleS
 				ToAccountNumber:   req.ToAccountNumber,
 				Currency:          req.Currency,
 				Amount:            req.Amount,
+				QuoteID:           req.QuoteId,
 			}
 
-			_, tansferSuccess, err := a.bankService.Transfer(tt)
+			_, tansferSuccess, err := a.bankService.Transfer(ctx, tt)
 			if err != nil {
-				log.Printf("failed to transfer transaction: %v\n", err)
-				return buildTransferErrorStatusGrpc(err, req)
+				logger.WarnContext(ctx, "failed to transfer transaction", "err", err)
 			}
 
 			res := bank.TransferResponse{
@@ -243,77 +529,72 @@ func (a *GrpcAdapter) TransferMultiple(stream bank.BankService_TransferMultipleS
 				Timestamp:         currentDatetime(),
 			}
 
-			if tansferSuccess {
+			if tansferSuccess && err == nil {
 				res.Status = bank.TransferStatus_TRANSFER_STATUS_SUCCESS
 			} else {
 				res.Status = bank.TransferStatus_TRANSFER_STATUS_FAILED
+				res.Error = toTransferErrorGrpc(err, req)
 			}
 
 			err = stream.Send(&res)
 			if err != nil {
-				log.Printf("failed to send transfer response: %v\n", err)
+				logger.WarnContext(ctx, "failed to send transfer response", "err", err)
 				return err
 			}
 		}
 	}
 }
 
-func buildTransferErrorStatusGrpc(err error, req *bank.TransferRequest) error {
+// motivo da falha de um item de TransferMultiple, devolvido na resposta sem encerrar a stream
+func toTransferErrorGrpc(err error, req *bank.TransferRequest) *bank.TransferError {
 	switch {
+	case err == nil:
+		return &bank.TransferError{
+			ReasonCode: "TRANSFER_FAILED",
+			Message:    "transfer failed",
+		}
+	case errors.Is(err, domainBank.ErrTransferInvalidAmount):
+		return &bank.TransferError{
+			ReasonCode: "INVALID_AMOUNT",
+			Field:      "amount",
+			Message:    fmt.Sprintf("amount %v must be positive", req.Amount),
+		}
 	case errors.Is(err, domainBank.ErrTransferSourceAccountNotFound):
-		s := status.New(codes.FailedPrecondition, err.Error())
-		s, _ = s.WithDetails(&errdetails.PreconditionFailure{
-			Violations: []*errdetails.PreconditionFailure_Violation{
-				{
-					Type:        "INVALID_ACCOUNT",
-					Subject:     "Source account not found",
-					Description: fmt.Sprintf("source account (from %v) not found", req.FromAccountNumber),
-				},
-			},
-		})
-
-		return s.Err()
+		return &bank.TransferError{
+			ReasonCode: "INVALID_ACCOUNT",
+			Field:      "from_account_number",
+			Message:    fmt.Sprintf("source account (from %v) not found", req.FromAccountNumber),
+		}
 	case errors.Is(err, domainBank.ErrTransferDestinationAccountNotFound):
-		s := status.New(codes.FailedPrecondition, err.Error())
-		s, _ = s.WithDetails(&errdetails.PreconditionFailure{
-			Violations: []*errdetails.PreconditionFailure_Violation{
-				{
-					Type:        "INVALID_ACCOUNT",
-					Subject:     "Destination account not found",
-					Description: fmt.Sprintf("destination account (to %v) not found", req.ToAccountNumber),
-				},
-			},
-		})
-
-		return s.Err()
+		return &bank.TransferError{
+			ReasonCode: "INVALID_ACCOUNT",
+			Field:      "to_account_number",
+			Message:    fmt.Sprintf("destination account (to %v) not found", req.ToAccountNumber),
+		}
+	case errors.Is(err, domainBank.ErrExchangeQuoteNotFound),
+		errors.Is(err, domainBank.ErrExchangeQuoteExpired),
+		errors.Is(err, domainBank.ErrExchangeQuoteUsed),
+		errors.Is(err, domainBank.ErrExchangeQuoteMismatch):
+		return &bank.TransferError{
+			ReasonCode: "INVALID_QUOTE",
+			Field:      "quote_id",
+			Message:    fmt.Sprintf("quote %v cannot be applied: %v", req.QuoteId, err),
+		}
 	case errors.Is(err, domainBank.ErrTransferRecordFailed):
-		s := status.New(codes.Internal, err.Error())
-		s, _ = s.WithDetails(&errdetails.Help{
-			Links: []*errdetails.Help_Link{
-				{
-					Url:         "my-bank-website.com/faq",
-					Description: "Bank FAQ",
-				},
-			},
-		})
-
-		return s.Err()
+		return &bank.TransferError{
+			ReasonCode: "TRANSFER_RECORD_FAILED",
+			Message:    err.Error(),
+		}
 	case errors.Is(err, domainBank.ErrTransferTransactionPair):
-		s := status.New(codes.InvalidArgument, err.Error())
-		s, _ = s.WithDetails(&errdetails.ErrorInfo{
-			Domain: "my-bank-website.com",
-			Reason: "TRANSACTION_PAIR_FAILED",
-			Metadata: map[string]string{
-				"from_account": req.FromAccountNumber,
-				"to_account":   req.ToAccountNumber,
-				"currency":     req.Currency,
-				"amount":       fmt.Sprintf("%f", req.Amount),
-			},
-		})
-
-		return s.Err()
+		return &bank.TransferError{
+			ReasonCode: "TRANSACTION_PAIR_FAILED",
+			Field:      "amount",
+			Message:    err.Error(),
+		}
 	default:
-		s := status.New(codes.Unknown, err.Error())
-		return s.Err()
+		return &bank.TransferError{
+			ReasonCode: "UNKNOWN",
+			Message:    err.Error(),
+		}
 	}
 }
@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"errors"
+	"io"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/adapter/ingest"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/viquitorreis/my-grpc-proto/protogen/go/bank"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// o client envia o arquivo em chunks; formato e dry_run são lidos da primeira mensagem.
+// Os chunks passam por um pipe, então o arquivo é processado sem ser carregado inteiro em memória
+func (a *GrpcAdapter) ImportTransactions(stream bank.BankService_ImportTransactionsServer) error {
+	if err := a.authorize(stream.Context(), auth.ActionImportTransactions, ""); err != nil {
+		return err
+	}
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return buildInvalidArgumentStatusGrpc("chunk", "empty transaction file")
+	}
+
+	if err != nil {
+		logger.WarnContext(stream.Context(), "failed to receive transaction file from client", "err", err)
+		return err
+	}
+
+	format := ""
+	switch first.Format {
+	case bank.TransactionFileFormat_TRANSACTION_FILE_FORMAT_CSV:
+		format = ingest.FormatCSV
+	case bank.TransactionFileFormat_TRANSACTION_FILE_FORMAT_NDJSON:
+		format = ingest.FormatNDJSON
+	default:
+		return buildInvalidArgumentStatusGrpc("format", "file format must be CSV or NDJSON")
+	}
+
+	pr, pw := io.Pipe()
+	// fechar o leitor desbloqueia a goroutine se o handler terminar antes do fim do arquivo
+	defer pr.Close()
+
+	go func() {
+		if _, err := pw.Write(first.Chunk); err != nil {
+			return
+		}
+
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				pw.Close()
+				return
+			}
+
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+
+			if _, err := pw.Write(req.Chunk); err != nil {
+				return
+			}
+		}
+	}()
+
+	src, err := ingest.NewTransactionSource(format, pr)
+	if err != nil {
+		logger.WarnContext(stream.Context(), "failed to read transaction file", "err", err)
+		return buildInvalidArgumentStatusGrpc("chunk", err.Error())
+	}
+
+	res, err := a.transactionImporter.ImportTransactions(stream.Context(), src, first.DryRun)
+	if err != nil {
+		logger.ErrorContext(stream.Context(), "failed to import transactions", "err", err)
+
+		if errors.Is(err, stream.Context().Err()) {
+			return status.FromContextError(err).Err()
+		}
+
+		return status.Error(codes.Internal, "failed to import transactions")
+	}
+
+	out := &bank.ImportTransactionsResponse{
+		RowsRead:        int64(res.RowsRead),
+		RowsImported:    int64(res.RowsImported),
+		RowsFailed:      int64(res.RowsFailed),
+		DryRun:          res.DryRun,
+		Errors:          make([]*bank.ImportRowError, 0, len(res.Errors)),
+		ErrorsTruncated: res.ErrorsTruncated,
+	}
+
+	for _, e := range res.Errors {
+		out.Errors = append(out.Errors, &bank.ImportRowError{
+			Row:     int64(e.Row),
+			Field:   e.Field,
+			Message: e.Message,
+		})
+	}
+
+	return stream.SendAndClose(out)
+}
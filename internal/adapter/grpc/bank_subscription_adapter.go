@@ -0,0 +1,361 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	domainBank "github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+	"github.com/viquitorreis/my-grpc-proto/protogen/go/bank"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// atualizações recebidas do hub e ainda não processadas pela stream
+const exchangeRateSessionBufferSize = 64
+
+type exchangeRatePairKey struct {
+	fromCurrency string
+	toCurrency   string
+}
+
+type exchangeRateUpdate struct {
+	key   exchangeRatePairKey
+	price domainBank.ExchangeRatePrice
+}
+
+type exchangeRatePairSubscription struct {
+	sub  port.ExchangeRateSubscriptionPort
+	stop chan struct{}
+}
+
+// estado de uma stream SubscribeExchangeRates: pares inscritos, modo de entrega e o que já foi enviado
+type exchangeRateSession struct {
+	hub          port.ExchangeRateHubPort
+	stream       bank.BankService_SubscribeExchangeRatesServer
+	resolveTier  func(ctx context.Context, requested string) string
+	customerTier string
+	mode         string
+	interval     time.Duration
+	subs         map[exchangeRatePairKey]*exchangeRatePairSubscription
+	latest       map[exchangeRatePairKey]domainBank.ExchangeRatePrice
+	sent         map[exchangeRatePairKey]domainBank.ExchangeRatePrice
+	sentAt       map[exchangeRatePairKey]time.Time
+	pending      map[exchangeRatePairKey]bool
+	updates      chan exchangeRateUpdate
+	closed       chan error
+}
+
+func (a *GrpcAdapter) SubscribeExchangeRates(stream bank.BankService_SubscribeExchangeRatesServer) error {
+	ctx := stream.Context()
+
+	session := &exchangeRateSession{
+		hub:          a.exchangeRateHub,
+		stream:       stream,
+		resolveTier:  a.customerTier,
+		customerTier: a.customerTier(ctx, domainBank.CustomerTierDefault),
+		mode:         domainBank.DeliveryModeOnChange,
+		interval:     domainBank.DefaultDeliveryInterval,
+		subs:         make(map[exchangeRatePairKey]*exchangeRatePairSubscription),
+		latest:       make(map[exchangeRatePairKey]domainBank.ExchangeRatePrice),
+		sent:         make(map[exchangeRatePairKey]domainBank.ExchangeRatePrice),
+		sentAt:       make(map[exchangeRatePairKey]time.Time),
+		pending:      make(map[exchangeRatePairKey]bool),
+		updates:      make(chan exchangeRateUpdate, exchangeRateSessionBufferSize),
+		closed:       make(chan error, 1),
+	}
+	defer session.removeAll()
+
+	// Recv bloqueia, então as mensagens do client são lidas em outra goroutine
+	requests := make(chan *bank.ExchangeRateSubscriptionRequest)
+	recvErr := make(chan error, 1)
+
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(session.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "client cancelled the stream")
+			return nil
+		case err := <-recvErr:
+			if err == io.EOF {
+				return nil
+			}
+
+			logger.WarnContext(ctx, "failed to receive exchange rate subscription from client", "err", err)
+			return err
+		case req := <-requests:
+			if err := session.apply(req); err != nil {
+				return err
+			}
+
+			ticker.Reset(session.interval)
+		case err := <-session.closed:
+			logger.WarnContext(ctx, "exchange rate subscription closed", "err", err)
+			return status.Error(codes.ResourceExhausted, err.Error())
+		case u := <-session.updates:
+			if err := session.receive(u); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := session.tick(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *exchangeRateSession) apply(req *bank.ExchangeRateSubscriptionRequest) error {
+	switch req.DeliveryMode {
+	case bank.DeliveryMode_DELIVERY_MODE_ON_CHANGE:
+		s.mode = domainBank.DeliveryModeOnChange
+	case bank.DeliveryMode_DELIVERY_MODE_INTERVAL:
+		s.mode = domainBank.DeliveryModeInterval
+	case bank.DeliveryMode_DELIVERY_MODE_THROTTLED:
+		s.mode = domainBank.DeliveryModeThrottled
+	}
+
+	if req.IntervalMillis > 0 {
+		interval := time.Duration(req.IntervalMillis) * time.Millisecond
+		if interval < domainBank.MinDeliveryInterval {
+			return buildInvalidArgumentStatusGrpc("interval_millis",
+				fmt.Sprintf("interval must be at least %v", domainBank.MinDeliveryInterval))
+		}
+
+		s.interval = interval
+	}
+
+	// trocar o tier muda o spread, então todos os pares são reinscritos
+	if tier := s.resolveTier(s.stream.Context(), req.CustomerTier); req.CustomerTier != "" && tier != s.customerTier {
+		s.customerTier = tier
+
+		keys := make([]exchangeRatePairKey, 0, len(s.subs))
+		for key := range s.subs {
+			keys = append(keys, key)
+		}
+
+		for _, key := range keys {
+			s.remove(key)
+			if err := s.add(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	switch req.Action {
+	case bank.SubscriptionAction_SUBSCRIPTION_ACTION_ADD:
+		if len(req.Pairs) == 0 {
+			return buildInvalidArgumentStatusGrpc("pairs", "at least one currency pair is required")
+		}
+
+		for _, p := range req.Pairs {
+			if p.FromCurrency == "" || p.ToCurrency == "" {
+				return buildInvalidArgumentStatusGrpc("pairs", "from_currency and to_currency are required")
+			}
+
+			key := exchangeRatePairKey{fromCurrency: p.FromCurrency, toCurrency: p.ToCurrency}
+			if _, ok := s.subs[key]; ok {
+				continue
+			}
+
+			if err := s.add(key); err != nil {
+				return err
+			}
+		}
+	case bank.SubscriptionAction_SUBSCRIPTION_ACTION_REMOVE:
+		for _, p := range req.Pairs {
+			s.remove(exchangeRatePairKey{fromCurrency: p.FromCurrency, toCurrency: p.ToCurrency})
+		}
+	}
+
+	return nil
+}
+
+func (s *exchangeRateSession) add(key exchangeRatePairKey) error {
+	ctx := s.stream.Context()
+
+	sub, err := s.hub.Subscribe(ctx, key.fromCurrency, key.toCurrency, s.customerTier)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to subscribe exchange rate", "from_currency", key.fromCurrency, "to_currency", key.toCurrency, "err", err)
+		st := status.New(codes.FailedPrecondition, "failed to get exchange rate")
+		st, _ = st.WithDetails(&errdetails.ErrorInfo{
+			Domain: "bank.com",
+			Reason: "failed to get exchange rate",
+			Metadata: map[string]string{
+				"from_currency": key.fromCurrency,
+				"to_currency":   key.toCurrency,
+			},
+		})
+
+		return st.Err()
+	}
+
+	pairSub := &exchangeRatePairSubscription{
+		sub:  sub,
+		stop: make(chan struct{}),
+	}
+	s.subs[key] = pairSub
+
+	// encaminha as atualizações do par para o canal único da sessão
+	go func() {
+		for {
+			select {
+			case <-pairSub.stop:
+				return
+			case <-sub.Done():
+				if err := sub.Err(); err != nil {
+					select {
+					case s.closed <- err:
+					default:
+					}
+				}
+				return
+			case price := <-sub.Updates():
+				select {
+				case s.updates <- exchangeRateUpdate{key: key, price: price}:
+				case <-pairSub.stop:
+					return
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *exchangeRateSession) remove(key exchangeRatePairKey) {
+	pairSub, ok := s.subs[key]
+	if !ok {
+		return
+	}
+
+	close(pairSub.stop)
+	pairSub.sub.Close()
+
+	delete(s.subs, key)
+	delete(s.latest, key)
+	delete(s.sent, key)
+	delete(s.sentAt, key)
+	delete(s.pending, key)
+}
+
+func (s *exchangeRateSession) removeAll() {
+	for key := range s.subs {
+		s.remove(key)
+	}
+}
+
+func (s *exchangeRateSession) receive(u exchangeRateUpdate) error {
+	// atualização de um par removido que ainda estava no canal
+	if _, ok := s.subs[u.key]; !ok {
+		return nil
+	}
+
+	s.latest[u.key] = u.price
+
+	switch s.mode {
+	case domainBank.DeliveryModeOnChange:
+		if s.changed(u.key) {
+			return s.send(u.key)
+		}
+	case domainBank.DeliveryModeThrottled:
+		if !s.changed(u.key) {
+			return nil
+		}
+
+		if time.Since(s.sentAt[u.key]) >= s.interval {
+			return s.send(u.key)
+		}
+
+		s.pending[u.key] = true
+	}
+
+	return nil
+}
+
+func (s *exchangeRateSession) tick() error {
+	switch s.mode {
+	case domainBank.DeliveryModeInterval:
+		for key := range s.latest {
+			if err := s.send(key); err != nil {
+				return err
+			}
+		}
+	case domainBank.DeliveryModeThrottled:
+		for key := range s.pending {
+			if err := s.send(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *exchangeRateSession) changed(key exchangeRatePairKey) bool {
+	sent, ok := s.sent[key]
+	if !ok {
+		return true
+	}
+
+	latest := s.latest[key]
+
+	return sent.MidRate != latest.MidRate || sent.BuyRate != latest.BuyRate || sent.SellRate != latest.SellRate
+}
+
+func (s *exchangeRateSession) send(key exchangeRatePairKey) error {
+	price := s.latest[key]
+
+	err := s.stream.Send(&bank.ExchangeRateResponse{
+		FromCurrency: key.fromCurrency,
+		ToCurrency:   key.toCurrency,
+		Rate:         price.MidRate,
+		BuyRate:      price.BuyRate,
+		SellRate:     price.SellRate,
+		Timestamp:    time.Now().Truncate(time.Second).Format(time.RFC3339),
+	})
+	if err != nil {
+		logger.WarnContext(s.stream.Context(), "failed to send exchange rate", "err", err)
+		return err
+	}
+
+	s.sent[key] = price
+	s.sentAt[key] = time.Now()
+	delete(s.pending, key)
+
+	return nil
+}
+
+func buildInvalidArgumentStatusGrpc(field, description string) error {
+	s := status.New(codes.InvalidArgument, description)
+	s, _ = s.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{
+				Field:       field,
+				Description: description,
+			},
+		},
+	})
+
+	return s.Err()
+}
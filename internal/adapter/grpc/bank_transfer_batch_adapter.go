@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+
+	domainBank "github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-proto/protogen/go/bank"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type transferBatch struct {
+	id       string
+	requests []*bank.TransferRequest
+}
+
+// acumula o request no lote aberto e executa o lote quando o client marca batch_commit.
+// Retorna o lote que continua aberto (nil depois do commit)
+func (a *GrpcAdapter) addToTransferBatch(stream bank.BankService_TransferMultipleServer, batch *transferBatch,
+	req *bank.TransferRequest) (*transferBatch, error) {
+	// um lote precisa ser fechado antes de começar outro ou voltar ao modo individual
+	if batch != nil && req.BatchId != batch.id {
+		return nil, buildTransferBatchErrorStatusGrpc(domainBank.ErrTransferBatchNotCommitted, batch.id)
+	}
+
+	if batch == nil {
+		batch = &transferBatch{id: req.BatchId}
+	}
+
+	batch.requests = append(batch.requests, req)
+
+	if len(batch.requests) > domainBank.MaxTransferBatchSize {
+		return nil, buildTransferBatchErrorStatusGrpc(domainBank.ErrTransferBatchTooLarge, batch.id)
+	}
+
+	if !req.BatchCommit {
+		return batch, nil
+	}
+
+	return nil, a.executeTransferBatch(stream, batch)
+}
+
+// responde um TransferResponse por item com o resultado do item e o resultado do lote
+func (a *GrpcAdapter) executeTransferBatch(stream bank.BankService_TransferMultipleServer, batch *transferBatch) error {
+	tts := make([]domainBank.TransferTransaction, 0, len(batch.requests))
+	for _, req := range batch.requests {
+		tts = append(tts, domainBank.TransferTransaction{
+			FromAccountNumber: req.FromAccountNumber,
+			ToAccountNumber:   req.ToAccountNumber,
+			Currency:          req.Currency,
+			Amount:            req.Amount,
+			QuoteID:           req.QuoteId,
+		})
+	}
+
+	outcome := bank.TransferBatchOutcome_TRANSFER_BATCH_OUTCOME_COMMITTED
+	failedIndex := -1
+
+	var batchErr *domainBank.TransferBatchError
+
+	ctx := stream.Context()
+
+	if _, err := a.bankService.TransferBatch(ctx, tts); err != nil {
+		logger.WarnContext(ctx, "transfer batch rolled back", "batch_id", batch.id, "err", err)
+		outcome = bank.TransferBatchOutcome_TRANSFER_BATCH_OUTCOME_ROLLED_BACK
+
+		if errors.As(err, &batchErr) {
+			failedIndex = batchErr.Index
+		}
+	}
+
+	for i, req := range batch.requests {
+		res := bank.TransferResponse{
+			FromAccountNumber: req.FromAccountNumber,
+			ToAccountNumber:   req.ToAccountNumber,
+			Currency:          req.Currency,
+			Amount:            req.Amount,
+			Timestamp:         currentDatetime(),
+			BatchId:           batch.id,
+			BatchIndex:        int32(i),
+			BatchOutcome:      outcome,
+		}
+
+		switch {
+		case outcome == bank.TransferBatchOutcome_TRANSFER_BATCH_OUTCOME_COMMITTED:
+			res.Status = bank.TransferStatus_TRANSFER_STATUS_SUCCESS
+		case i == failedIndex:
+			res.Status = bank.TransferStatus_TRANSFER_STATUS_FAILED
+			res.Error = toTransferErrorGrpc(batchErr.Err, req)
+		default:
+			res.Status = bank.TransferStatus_TRANSFER_STATUS_ROLLED_BACK
+		}
+
+		if err := stream.Send(&res); err != nil {
+			logger.WarnContext(ctx, "failed to send transfer response", "err", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func buildTransferBatchErrorStatusGrpc(err error, batchID string) error {
+	s := status.New(codes.InvalidArgument, err.Error())
+	s, _ = s.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{
+				Field:       "batch_id",
+				Description: fmt.Sprintf("batch %v: %v", batchID, err),
+			},
+		},
+	})
+
+	return s.Err()
+}
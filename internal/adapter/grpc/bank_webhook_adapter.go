@@ -0,0 +1,169 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	domainBank "github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/webhook"
+	"github.com/viquitorreis/my-grpc-proto/protogen/go/bank"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *GrpcAdapter) CreateWebhookSubscription(ctx context.Context, req *bank.CreateWebhookSubscriptionRequest) (*bank.WebhookSubscription, error) {
+	if err := a.authorize(ctx, auth.ActionManageWebhooks, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	sub, err := a.webhookService.CreateSubscription(ctx, req.AccountNumber, req.EventType, req.Url)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to create webhook subscription", "err", err)
+		return nil, buildWebhookErrorStatusGrpc(err, "failed to create webhook subscription")
+	}
+
+	return &bank.WebhookSubscription{
+		SubscriptionId:   sub.SubscriptionUUID.String(),
+		AccountNumber:    sub.AccountNumber,
+		EventType:        sub.EventType,
+		Url:              sub.URL,
+		Secret:           sub.Secret,
+		CreatedTimestamp: toDatetime(sub.CreatedAt),
+	}, nil
+}
+
+func (a *GrpcAdapter) DeleteWebhookSubscription(ctx context.Context, req *bank.DeleteWebhookSubscriptionRequest) (*bank.DeleteWebhookSubscriptionResponse, error) {
+	if err := a.authorize(ctx, auth.ActionOperateWebhooks, ""); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.SubscriptionId)
+	if err != nil {
+		return nil, buildInvalidArgumentStatusGrpc("subscription_id", "invalid subscription id")
+	}
+
+	if err := a.webhookService.DeleteSubscription(ctx, id); err != nil {
+		logger.ErrorContext(ctx, "failed to delete webhook subscription", "subscription_id", id, "err", err)
+		return nil, buildWebhookErrorStatusGrpc(err, "failed to delete webhook subscription")
+	}
+
+	return &bank.DeleteWebhookSubscriptionResponse{}, nil
+}
+
+func (a *GrpcAdapter) ListWebhookDeliveries(ctx context.Context, req *bank.ListWebhookDeliveriesRequest) (*bank.ListWebhookDeliveriesResponse, error) {
+	// sem conta a listagem cobre todas as contas e fica restrita ao admin
+	action := auth.ActionManageWebhooks
+	if req.AccountNumber == "" {
+		action = auth.ActionOperateWebhooks
+	}
+
+	if err := a.authorize(ctx, action, req.AccountNumber); err != nil {
+		return nil, err
+	}
+
+	deliveries, nextPageToken, err := a.webhookService.ListDeliveries(ctx, req.AccountNumber, toWebhookDeliveryStatus(req.Status),
+		int(req.PageSize), req.PageToken)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to list webhook deliveries", "err", err)
+		return nil, buildWebhookErrorStatusGrpc(err, "failed to list webhook deliveries")
+	}
+
+	res := &bank.ListWebhookDeliveriesResponse{
+		Deliveries:    make([]*bank.WebhookDelivery, 0, len(deliveries)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, d := range deliveries {
+		res.Deliveries = append(res.Deliveries, toWebhookDeliveryGrpc(d))
+	}
+
+	return res, nil
+}
+
+func (a *GrpcAdapter) ReplayWebhookDelivery(ctx context.Context, req *bank.ReplayWebhookDeliveryRequest) (*bank.WebhookDelivery, error) {
+	if err := a.authorize(ctx, auth.ActionOperateWebhooks, ""); err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(req.DeliveryId)
+	if err != nil {
+		return nil, buildInvalidArgumentStatusGrpc("delivery_id", "invalid delivery id")
+	}
+
+	delivery, err := a.webhookService.ReplayDelivery(ctx, id)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to replay webhook delivery", "delivery_id", id, "err", err)
+		return nil, buildWebhookErrorStatusGrpc(err, "failed to replay webhook delivery")
+	}
+
+	return toWebhookDeliveryGrpc(delivery), nil
+}
+
+func toWebhookDeliveryStatus(s bank.WebhookDeliveryStatus) string {
+	switch s {
+	case bank.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING:
+		return webhook.DeliveryStatusPending
+	case bank.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED:
+		return webhook.DeliveryStatusDelivered
+	case bank.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED:
+		return webhook.DeliveryStatusFailed
+	}
+
+	return ""
+}
+
+func toWebhookDeliveryStatusGrpc(s string) bank.WebhookDeliveryStatus {
+	switch s {
+	case webhook.DeliveryStatusPending:
+		return bank.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING
+	case webhook.DeliveryStatusDelivered:
+		return bank.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED
+	case webhook.DeliveryStatusFailed:
+		return bank.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_FAILED
+	}
+
+	return bank.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+}
+
+func toWebhookDeliveryGrpc(d webhook.Delivery) *bank.WebhookDelivery {
+	res := &bank.WebhookDelivery{
+		DeliveryId:       d.DeliveryUUID.String(),
+		SubscriptionId:   d.SubscriptionUUID.String(),
+		EventId:          d.EventUUID.String(),
+		EventType:        d.EventType,
+		Status:           toWebhookDeliveryStatusGrpc(d.Status),
+		Attempts:         int32(d.Attempts),
+		LastStatusCode:   int32(d.LastStatusCode),
+		LastError:        d.LastError,
+		CreatedTimestamp: toDatetime(d.CreatedAt),
+	}
+
+	if d.Status == webhook.DeliveryStatusPending {
+		res.NextAttemptTimestamp = toDatetime(d.NextAttemptAt)
+	}
+
+	if d.DeliveredAt != nil {
+		res.DeliveredTimestamp = toDatetime(*d.DeliveredAt)
+	}
+
+	return res
+}
+
+func buildWebhookErrorStatusGrpc(err error, msg string) error {
+	switch {
+	case errors.Is(err, webhook.ErrInvalidURL), errors.Is(err, webhook.ErrForbiddenDestination):
+		return buildInvalidArgumentStatusGrpc("url", err.Error())
+	case errors.Is(err, webhook.ErrInvalidEventType):
+		return buildInvalidArgumentStatusGrpc("event_type", err.Error())
+	case errors.Is(err, webhook.ErrInvalidDeliveryStatus):
+		return buildInvalidArgumentStatusGrpc("status", err.Error())
+	case errors.Is(err, domainBank.ErrInvalidPageToken):
+		return buildInvalidArgumentStatusGrpc("page_token", err.Error())
+	case errors.Is(err, webhook.ErrSubscriptionNotFound), errors.Is(err, webhook.ErrDeliveryNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return status.Error(codes.FailedPrecondition, msg)
+}
@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/health"
+	"github.com/viquitorreis/my-grpc-proto/protogen/go/bank"
+	"github.com/viquitorreis/my-grpc-proto/protogen/go/hello"
+	"github.com/viquitorreis/my-grpc-proto/protogen/go/resiliency"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// hello e resiliency não têm dependências externas e ficam SERVING até o shutdown.
+// bank e o status geral ("") só ficam SERVING depois da primeira verificação saudável
+func (a *GrpcAdapter) initHealth() {
+	a.health.SetServingStatus(hello.HelloService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	a.health.SetServingStatus(resiliency.ResiliencyService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	a.health.SetServingStatus(resiliency.ResiliencyWithMetadataService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	a.health.SetServingStatus(bank.BankService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	a.health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// verifica o banco e o feed de taxas a cada interval e atualiza o status do bank até ctx ser cancelado
+func (a *GrpcAdapter) MonitorHealth(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *health.Report
+
+	for {
+		report := a.healthService.Check(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if last == nil || last.Healthy() != report.Healthy() {
+			logHealthReport(ctx, report)
+		}
+		last = &report
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if !report.Healthy() {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		// depois do Shutdown o health server ignora essas chamadas e mantém NOT_SERVING
+		a.health.SetServingStatus(bank.BankService_ServiceDesc.ServiceName, status)
+		a.health.SetServingStatus("", status)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func logHealthReport(ctx context.Context, report health.Report) {
+	if report.Healthy() {
+		logger.InfoContext(ctx, "bank service is healthy", "database_ping", report.DatabaseLatency.Round(time.Millisecond))
+		return
+	}
+
+	if report.DatabaseErr != nil {
+		logger.WarnContext(ctx, "bank service is unhealthy", "err", report.DatabaseErr)
+	}
+
+	if report.RateFeedErr != nil {
+		logger.WarnContext(ctx, "bank service is unhealthy", "err", report.RateFeedErr)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 
 	"github.com/viquitorreis/my-grpc-proto/protogen/go/hello"
 )
@@ -51,7 +50,8 @@ func (a *GrpcAdapter) SayHelloToEveryone(stream hello.HelloService_SayHelloToEve
 		}
 
 		if err != nil {
-			log.Fatalln("Error receiving stream:", err)
+			logger.WarnContext(stream.Context(), "failed to receive stream", "err", err)
+			return err
 		}
 
 		greet := a.helloService.GenerateHello(req.Name)
@@ -68,7 +68,8 @@ func (a *GrpcAdapter) SayHelloContinuous(stream hello.HelloService_SayHelloConti
 		}
 
 		if err != nil {
-			log.Fatalln("Error receiving stream:", err)
+			logger.WarnContext(stream.Context(), "failed to receive stream", "err", err)
+			return err
 		}
 
 		// para cada request vamos gerar uma resposta e retornar para o client gRPC imediatamente
@@ -80,7 +81,8 @@ func (a *GrpcAdapter) SayHelloContinuous(stream hello.HelloService_SayHelloConti
 		)
 
 		if err != nil {
-			log.Fatalln("Error sending stream:", err)
+			logger.WarnContext(stream.Context(), "failed to send stream response", "err", err)
+			return err
 		}
 	}
 }
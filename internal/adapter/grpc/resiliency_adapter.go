@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"strconv"
 
 	domainResiliency "github.com/viquitorreis/my-grpc-go-server/internal/application/resiliency"
@@ -29,7 +28,7 @@ func generateErrStatus(statusCode uint32) error {
 }
 
 func (a *GrpcAdapter) UnaryResiliency(ctx context.Context, req *resiliency.ResiliencyRequest) (*resiliency.ResiliencyReponse, error) {
-	log.Println("UnaryResiliency called")
+	logger.DebugContext(ctx, "UnaryResiliency called")
 	str, sts := a.resiliencyService.GenerateResiliency(req.MinDelaySecond, req.MaxDelaySecond, req.StatusCodes)
 
 	if err := generateErrStatus(sts); err != nil {
@@ -42,13 +41,13 @@ func (a *GrpcAdapter) UnaryResiliency(ctx context.Context, req *resiliency.Resil
 }
 
 func (a *GrpcAdapter) ServerStreamResiliency(req *resiliency.ResiliencyRequest, stream resiliency.ResiliencyService_ServerStreamResiliencyServer) error {
-	log.Println("ServerStreamResiliency called")
-	context := stream.Context()
+	ctx := stream.Context()
+	logger.DebugContext(ctx, "ServerStreamResiliency called")
 
 	for {
 		select {
-		case <-context.Done():
-			log.Println("client cancelou o streaming")
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "client cancelled the stream")
 			return nil
 		default:
 			str, sts := a.resiliencyService.GenerateResiliency(req.MinDelaySecond, req.MaxDelaySecond, req.StatusCodes)
@@ -65,7 +64,7 @@ func (a *GrpcAdapter) ServerStreamResiliency(req *resiliency.ResiliencyRequest,
 }
 
 func (a *GrpcAdapter) ClientStreamResiliency(stream resiliency.ResiliencyService_ClientStreamResiliencyServer) error {
-	log.Println("ClientStreamResiliency called")
+	logger.DebugContext(stream.Context(), "ClientStreamResiliency called")
 
 	i := 0
 
@@ -73,7 +72,7 @@ func (a *GrpcAdapter) ClientStreamResiliency(stream resiliency.ResiliencyService
 		req, err := stream.Recv()
 		if err != nil {
 			if err == io.EOF {
-				log.Println("ClientStreamResiliency finalizado")
+				logger.DebugContext(stream.Context(), "client stream finished", "requests", i)
 
 				res := resiliency.ResiliencyReponse{
 					DummyString: fmt.Sprintf("Recebeu %v requisições do client", strconv.Itoa(i)),
@@ -82,7 +81,7 @@ func (a *GrpcAdapter) ClientStreamResiliency(stream resiliency.ResiliencyService
 				return stream.SendAndClose(&res)
 			}
 
-			log.Printf("Error receiving stream: %v", err)
+			logger.WarnContext(stream.Context(), "failed to receive stream", "err", err)
 			return err
 		}
 
@@ -90,7 +89,7 @@ func (a *GrpcAdapter) ClientStreamResiliency(stream resiliency.ResiliencyService
 			_, sts := a.resiliencyService.GenerateResiliency(req.MinDelaySecond, req.MaxDelaySecond, req.StatusCodes)
 
 			if err := generateErrStatus(sts); err != nil {
-				log.Printf("Error generating error status: %v", err)
+				logger.InfoContext(stream.Context(), "returning generated error status", "err", err)
 				return err
 			}
 		}
@@ -100,13 +99,13 @@ func (a *GrpcAdapter) ClientStreamResiliency(stream resiliency.ResiliencyService
 }
 
 func (a *GrpcAdapter) BidirectionalStreamResiliency(stream resiliency.ResiliencyService_BidirectionalStreamResiliencyServer) error {
-	log.Println("BidirectionalStreamResiliency called")
+	ctx := stream.Context()
+	logger.DebugContext(ctx, "BidirectionalStreamResiliency called")
 
-	context := stream.Context()
 	for {
 		select {
-		case <-context.Done():
-			log.Println("client cancelou o streaming")
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "client cancelled the stream")
 			return nil
 		default:
 			req, err := stream.Recv()
@@ -116,7 +115,8 @@ func (a *GrpcAdapter) BidirectionalStreamResiliency(stream resiliency.Resiliency
 			}
 
 			if err != nil {
-				log.Fatalln("Erro ao ler stream do client:", err)
+				logger.WarnContext(ctx, "failed to receive stream", "err", err)
+				return err
 			}
 
 			str, sts := a.resiliencyService.GenerateResiliency(req.MinDelaySecond, req.MaxDelaySecond, req.StatusCodes)
@@ -130,7 +130,8 @@ func (a *GrpcAdapter) BidirectionalStreamResiliency(stream resiliency.Resiliency
 			})
 
 			if err != nil {
-				log.Fatalln("Erro ao enviar response da stream para o client:", err)
+				logger.WarnContext(ctx, "failed to send stream response", "err", err)
+				return err
 			}
 		}
 	}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"math/rand"
 	"strconv"
 	"time"
@@ -19,12 +18,9 @@ import (
 
 func dummyRequestMetadata(ctx context.Context) {
 	if requestMetadata, ok := metadata.FromIncomingContext(ctx); ok {
-		log.Println("Request metadata: ")
-		for k, v := range requestMetadata {
-			log.Printf(" %v: %v\n", k, v)
-		}
+		logger.DebugContext(ctx, "request metadata", "metadata", requestMetadata)
 	} else {
-		log.Println("Request metadata not found")
+		logger.DebugContext(ctx, "request metadata not found")
 	}
 }
 
@@ -40,7 +36,7 @@ func dummyResponseMetadata() metadata.MD {
 
 // UnaryResiliencyWithMetadata(context.Context, *ResiliencyRequest) (*ResiliencyReponse, error)
 func (a *GrpcAdapter) UnaryResiliencyWithMetadata(ctx context.Context, req *resiliency.ResiliencyRequest) (*resiliency.ResiliencyReponse, error) {
-	log.Println("UnaryResiliencyWithMetadata called")
+	logger.DebugContext(ctx, "UnaryResiliencyWithMetadata called")
 
 	randomDelay := time.Duration(rand.Intn(int(req.MaxDelaySecond-req.MinDelaySecond))+int(req.MinDelaySecond)) * time.Second
 	time.Sleep(randomDelay)
@@ -61,18 +57,18 @@ func (a *GrpcAdapter) UnaryResiliencyWithMetadata(ctx context.Context, req *resi
 }
 
 func (a *GrpcAdapter) ServerStreamResiliencyWithMetadata(req *resiliency.ResiliencyRequest, stream resiliency.ResiliencyWithMetadataService_ServerStreamResiliencyWithMetadataServer) error {
-	log.Println("ServerStreamResiliencyWithMetadata called")
-	context := stream.Context()
+	ctx := stream.Context()
+	logger.DebugContext(ctx, "ServerStreamResiliencyWithMetadata called")
 
-	dummyRequestMetadata(context)
+	dummyRequestMetadata(ctx)
 	if err := stream.SendHeader(dummyResponseMetadata()); err != nil {
-		log.Println("Error while sending response metadata: ", err)
+		logger.WarnContext(ctx, "failed to send response metadata", "err", err)
 	}
 
 	for {
 		select {
-		case <-context.Done():
-			log.Println("client cancelou o streaming")
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "client cancelled the stream")
 			return nil
 		default:
 			str, sts := a.resiliencyService.GenerateResiliency(req.MinDelaySecond, req.MaxDelaySecond, req.StatusCodes)
@@ -89,7 +85,7 @@ func (a *GrpcAdapter) ServerStreamResiliencyWithMetadata(req *resiliency.Resilie
 }
 
 func (a *GrpcAdapter) ClientStreamResiliencyWithMetadata(stream resiliency.ResiliencyWithMetadataService_ClientStreamResiliencyWithMetadataServer) error {
-	log.Println("ClientStreamResiliencyWithMetadata called")
+	logger.DebugContext(stream.Context(), "ClientStreamResiliencyWithMetadata called")
 
 	i := 0
 
@@ -97,7 +93,7 @@ func (a *GrpcAdapter) ClientStreamResiliencyWithMetadata(stream resiliency.Resil
 		req, err := stream.Recv()
 		if err != nil {
 			if err == io.EOF {
-				log.Println("ClientStreamResiliency finalizado")
+				logger.DebugContext(stream.Context(), "client stream finished", "requests", i)
 
 				res := resiliency.ResiliencyReponse{
 					DummyString: fmt.Sprintf("Recebeu %v requisições do client", strconv.Itoa(i)),
@@ -105,25 +101,25 @@ func (a *GrpcAdapter) ClientStreamResiliencyWithMetadata(stream resiliency.Resil
 
 				// precisamos enviar o header antes de terminar o streaming
 				if err := stream.SendHeader(dummyResponseMetadata()); err != nil {
-					log.Println("Error while sendint response metadata: ", err)
+					logger.WarnContext(stream.Context(), "failed to send response metadata", "err", err)
 				}
 
 				return stream.SendAndClose(&res)
 			}
 
-			log.Printf("Error receiving stream: %v", err)
+			logger.WarnContext(stream.Context(), "failed to receive stream", "err", err)
 			return err
 		}
 
 		// processando metadados
-		context := stream.Context()
-		dummyRequestMetadata(context)
+		ctx := stream.Context()
+		dummyRequestMetadata(ctx)
 
 		if req != nil {
 			_, sts := a.resiliencyService.GenerateResiliency(req.MinDelaySecond, req.MaxDelaySecond, req.StatusCodes)
 
 			if err := generateErrStatus(sts); err != nil {
-				log.Printf("Error generating error status: %v", err)
+				logger.InfoContext(stream.Context(), "returning generated error status", "err", err)
 				return err
 			}
 		}
@@ -133,17 +129,17 @@ func (a *GrpcAdapter) ClientStreamResiliencyWithMetadata(stream resiliency.Resil
 }
 
 func (a *GrpcAdapter) BidirectionalStreamResiliencyWithMetadata(stream resiliency.ResiliencyWithMetadataService_BidirectionalStreamResiliencyWithMetadataServer) error {
-	log.Println("BidirectionalStreamResiliencyWithMetadata called")
+	ctx := stream.Context()
+	logger.DebugContext(ctx, "BidirectionalStreamResiliencyWithMetadata called")
 
-	context := stream.Context()
 	if err := stream.SendHeader(dummyResponseMetadata()); err != nil {
-		log.Println("Error while sendint response metadata: ", err)
+		logger.WarnContext(ctx, "failed to send response metadata", "err", err)
 	}
 
 	for {
 		select {
-		case <-context.Done():
-			log.Println("client cancelou o streaming")
+		case <-ctx.Done():
+			logger.InfoContext(ctx, "client cancelled the stream")
 			return nil
 		default:
 			req, err := stream.Recv()
@@ -153,10 +149,11 @@ func (a *GrpcAdapter) BidirectionalStreamResiliencyWithMetadata(stream resilienc
 			}
 
 			if err != nil {
-				log.Fatalln("Erro ao ler stream do client:", err)
+				logger.WarnContext(ctx, "failed to receive stream", "err", err)
+				return err
 			}
 
-			dummyRequestMetadata(context)
+			dummyRequestMetadata(ctx)
 
 			str, sts := a.resiliencyService.GenerateResiliency(req.MinDelaySecond, req.MaxDelaySecond, req.StatusCodes)
 
@@ -169,7 +166,8 @@ func (a *GrpcAdapter) BidirectionalStreamResiliencyWithMetadata(stream resilienc
 			})
 
 			if err != nil {
-				log.Fatalln("Erro ao enviar response da stream para o client:", err)
+				logger.WarnContext(ctx, "failed to send stream response", "err", err)
+				return err
 			}
 		}
 	}
@@ -1,71 +1,170 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"net"
+	"time"
 
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+	"github.com/viquitorreis/my-grpc-go-server/internal/interceptor"
+	"github.com/viquitorreis/my-grpc-go-server/internal/logging"
 	"github.com/viquitorreis/my-grpc-go-server/internal/port"
 	"github.com/viquitorreis/my-grpc-proto/protogen/go/bank"
 	"github.com/viquitorreis/my-grpc-proto/protogen/go/hello"
 	"github.com/viquitorreis/my-grpc-proto/protogen/go/resiliency"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
+var logger = logging.Logger("grpc")
+
 type GrpcAdapter struct {
-	helloService      port.HelloServicePort
-	bankService       port.BankServicePort
-	resiliencyService port.ResiliencyServicePort
-	grpcPort          int
-	server            *grpc.Server
+	helloService        port.HelloServicePort
+	bankService         port.BankServicePort
+	resiliencyService   port.ResiliencyServicePort
+	exchangeRateHub     port.ExchangeRateHubPort
+	accountEventHub     port.AccountEventHubPort
+	webhookService      port.WebhookServicePort
+	transactionImporter port.TransactionImportServicePort
+	healthService       port.HealthServicePort
+	cfg                 config.GrpcConfig
+	server              *grpc.Server
+	certificates        *certificateReloader
+	health              *health.Server
 	hello.HelloServiceServer
 	bank.BankServiceServer
 	resiliency.ResiliencyServiceServer
 	resiliency.ResiliencyWithMetadataServiceServer
 }
 
-func NewGrpcAdapter(helloService port.HelloServicePort, bankService port.BankServicePort, resServPort port.ResiliencyServicePort, grpcPort int) *GrpcAdapter {
-	return &GrpcAdapter{
-		helloService:      helloService,
-		bankService:       bankService,
-		resiliencyService: resServPort,
-		grpcPort:          grpcPort,
+func NewGrpcAdapter(helloService port.HelloServicePort, bankService port.BankServicePort, resServPort port.ResiliencyServicePort,
+	exchangeRateHub port.ExchangeRateHubPort, accountEventHub port.AccountEventHubPort, webhookService port.WebhookServicePort,
+	transactionImporter port.TransactionImportServicePort, healthService port.HealthServicePort, tokenVerifier port.TokenVerifierPort,
+	apiKeys port.APIKeyAuthenticatorPort, cfg config.GrpcConfig) (*GrpcAdapter, error) {
+	a := &GrpcAdapter{
+		helloService:        helloService,
+		bankService:         bankService,
+		resiliencyService:   resServPort,
+		exchangeRateHub:     exchangeRateHub,
+		accountEventHub:     accountEventHub,
+		webhookService:      webhookService,
+		transactionImporter: transactionImporter,
+		healthService:       healthService,
+		cfg:                 cfg,
 	}
-}
 
-func (a *GrpcAdapter) Run() {
-	var err error
+	var opts []grpc.ServerOption
 
-	listen, err := net.Listen("tcp", fmt.Sprintf(":%d", a.grpcPort))
-	if err != nil {
-		log.Fatal(err)
+	// o request id vem primeiro para os logs de todos os interceptors e handlers levarem o id
+	unaryInterceptors := []grpc.UnaryServerInterceptor{interceptor.RequestIDUnaryServerInterceptor()}
+	streamInterceptors := []grpc.StreamServerInterceptor{interceptor.RequestIDStreamServerInterceptor()}
+
+	if cfg.TLS.Enabled {
+		certificates, err := newCertificateReloader(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+
+		a.certificates = certificates
+		opts = append(opts, grpc.Creds(credentials.NewTLS(certificates.serverTLSConfig())))
+		unaryInterceptors = append(unaryInterceptors, interceptor.TLSIdentityUnaryServerInterceptor())
+		streamInterceptors = append(streamInterceptors, interceptor.TLSIdentityStreamServerInterceptor())
+	}
+
+	// antes da autenticação, para tentativas com credenciais inválidas também serem limitadas
+	if cfg.RateLimit.Enabled {
+		preAuth := interceptor.NewPeerRateLimits(cfg.RateLimit.PreAuth)
+		unaryInterceptors = append(unaryInterceptors, interceptor.RateLimitUnaryServerInterceptor(preAuth))
+		streamInterceptors = append(streamInterceptors, interceptor.RateLimitStreamServerInterceptor(preAuth))
 	}
 
-	log.Printf("gRPC server running on port %d", a.grpcPort)
+	if cfg.Auth.Enabled {
+		if tokenVerifier == nil && apiKeys == nil {
+			return nil, fmt.Errorf("auth is enabled but no token verifier or api key authenticator was configured")
+		}
+
+		public := interceptor.MethodAllowList(cfg.Auth.PublicMethods)
+		unaryInterceptors = append(unaryInterceptors, interceptor.AuthUnaryServerInterceptor(tokenVerifier, apiKeys, public))
+		streamInterceptors = append(streamInterceptors, interceptor.AuthStreamServerInterceptor(tokenVerifier, apiKeys, public))
+	}
+
+	// depois da autenticação, para os buckets serem do chamador autenticado
+	if cfg.RateLimit.Enabled {
+		limits := interceptor.NewRateLimits(cfg.RateLimit)
+		unaryInterceptors = append(unaryInterceptors, interceptor.RateLimitUnaryServerInterceptor(limits))
+		streamInterceptors = append(streamInterceptors, interceptor.RateLimitStreamServerInterceptor(limits))
+	}
 
-	grpcServer := grpc.NewServer(
 	// interceptor deve ficar dentro das options do server
-	// grpc.ChainUnaryInterceptor(
-	// 	interceptor.LogUnaryServerInterceptor(),
-	// 	interceptor.BasicUnaryServerInterceptor(),
-	// ),
-	// grpc.ChainStreamInterceptor(
-	// 	interceptor.LogStreamServerInterceptor(),
-	// 	interceptor.BasicStreamServerInterceptor(),
-	// ),
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 	)
-	a.server = grpcServer
 
-	hello.RegisterHelloServiceServer(grpcServer, a)
-	bank.RegisterBankServiceServer(grpcServer, a)
-	resiliency.RegisterResiliencyServiceServer(grpcServer, a)
-	resiliency.RegisterResiliencyWithMetadataServiceServer(grpcServer, a)
+	// o server é criado aqui para que Shutdown possa ser chamado antes ou durante o Run
+	a.server = grpc.NewServer(opts...)
+	a.health = health.NewServer()
+
+	hello.RegisterHelloServiceServer(a.server, a)
+	bank.RegisterBankServiceServer(a.server, a)
+	resiliency.RegisterResiliencyServiceServer(a.server, a)
+	resiliency.RegisterResiliencyWithMetadataServiceServer(a.server, a)
+	healthpb.RegisterHealthServer(a.server, a.health)
+	a.initHealth()
+
+	if cfg.Reflection {
+		reflection.Register(a.server)
+	}
+
+	return a, nil
+}
+
+// bloqueia até o server parar. Retorna nil quando a parada veio de Shutdown
+func (a *GrpcAdapter) Run() error {
+	listen, err := net.Listen("tcp", fmt.Sprintf(":%d", a.cfg.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on port %d: %w", a.cfg.Port, err)
+	}
+
+	logger.Info("gRPC server running", "port", a.cfg.Port, "tls", a.cfg.TLS.Enabled, "client_auth", a.cfg.TLS.ClientAuth)
+
+	if err := a.server.Serve(listen); err != nil && err != grpc.ErrServerStopped {
+		return fmt.Errorf("failed to serve gRPC: %w", err)
+	}
+
+	return nil
+}
 
-	if err = grpcServer.Serve(listen); err != nil {
-		log.Fatal(err)
+// recarrega os certificados TLS quando os arquivos mudam, até ctx ser cancelado. Sem TLS retorna direto
+func (a *GrpcAdapter) WatchCertificates(ctx context.Context) {
+	if a.certificates == nil {
+		return
 	}
+
+	a.certificates.watch(ctx, a.cfg.TLS.ReloadInterval)
 }
 
-func (a *GrpcAdapter) Stop() {
-	a.server.GracefulStop()
+// marca o health como NOT_SERVING, para de aceitar RPCs e espera as chamadas em andamento
+// por até gracePeriod. Depois disso as streams restantes têm o contexto cancelado
+func (a *GrpcAdapter) Shutdown(gracePeriod time.Duration) {
+	a.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		a.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		logger.Info("gRPC server stopped")
+	case <-time.After(gracePeriod):
+		logger.Warn("gRPC grace period expired, cancelling remaining RPCs", "grace_period", gracePeriod)
+		a.server.Stop()
+		<-stopped
+	}
 }
@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+)
+
+// mantém o certificado do servidor e a CA de clientes em memória e os recarrega quando os
+// arquivos mudam. Cada handshake pega a versão atual via GetConfigForClient
+type certificateReloader struct {
+	cfg config.TLSConfig
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+}
+
+func newCertificateReloader(cfg config.TLSConfig) (*certificateReloader, error) {
+	r := &certificateReloader{cfg: cfg}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *certificateReloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+
+	return files
+}
+
+func (r *certificateReloader) load() error {
+	modTimes := make(map[string]time.Time)
+	for _, f := range r.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			return fmt.Errorf("failed to stat TLS file: %w", err)
+		}
+
+		modTimes[f] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file: %w", err)
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("failed to parse client CA file %v: no PEM certificates found", r.cfg.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *certificateReloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, f := range r.files() {
+		info, err := os.Stat(f)
+		// arquivo sumido no meio de uma troca: espera a próxima verificação
+		if err != nil {
+			return false
+		}
+
+		if !info.ModTime().Equal(r.modTimes[f]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verifica os arquivos a cada interval até ctx ser cancelado. Uma recarga com erro mantém o certificado anterior
+func (r *certificateReloader) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+
+			if err := r.load(); err != nil {
+				logger.Error("failed to reload TLS certificates, keeping the previous ones", "err", err)
+				continue
+			}
+
+			logger.Info("TLS certificates reloaded", "cert_file", r.cfg.CertFile)
+		}
+	}
+}
+
+func (r *certificateReloader) serverTLSConfig() *tls.Config {
+	clientAuth := tls.NoClientCert
+	switch r.cfg.ClientAuth {
+	case config.ClientAuthRequest:
+		clientAuth = tls.VerifyClientCertIfGiven
+	case config.ClientAuthRequire:
+		clientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+				ClientCAs:    r.clientCAs,
+				ClientAuth:   clientAuth,
+			}, nil
+		},
+	}
+}
@@ -0,0 +1,340 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+	"github.com/viquitorreis/my-grpc-go-server/internal/interceptor"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const testClientSPIFFE = "spiffe://bank.test/partner-a"
+
+// CA, certificados de servidor e cliente gerados no teste, gravados em PEM num diretório temporário
+type testPKI struct {
+	dir    string
+	ca     *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+	caPool *x509.CertPool
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &testPKI{dir: t.TempDir(), ca: ca, caKey: key, caPool: x509.NewCertPool()}
+	p.caPool.AddCert(ca)
+	p.write(t, "ca.pem", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	return p
+}
+
+func (p *testPKI) path(name string) string {
+	return filepath.Join(p.dir, name)
+}
+
+func (p *testPKI) write(t *testing.T, name string, data []byte) {
+	t.Helper()
+
+	if err := os.WriteFile(p.path(name), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// assina tmpl com a CA e devolve o par em PEM
+func (p *testPKI) issue(t *testing.T, tmpl *x509.Certificate) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, p.ca, &key.PublicKey, p.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// grava server.pem/server-key.pem. O mtime é adiantado para a troca ser vista mesmo em
+// sistemas de arquivo com resolução de segundos
+func (p *testPKI) writeServerCert(t *testing.T, serial int64, mtime time.Time) {
+	t.Helper()
+
+	certPEM, keyPEM := p.issue(t, &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+
+	p.write(t, "server-key.pem", keyPEM)
+	p.write(t, "server.pem", certPEM)
+
+	for _, name := range []string{"server.pem", "server-key.pem"} {
+		if err := os.Chtimes(p.path(name), mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func (p *testPKI) clientCert(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	spiffe, _ := url.Parse(testClientSPIFFE)
+
+	certPEM, keyPEM := p.issue(t, &x509.Certificate{
+		SerialNumber: big.NewInt(100),
+		Subject:      pkix.Name{CommonName: "partner-a"},
+		DNSNames:     []string{"partner-a.bank.test"},
+		URIs:         []*url.URL{spiffe},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert
+}
+
+func (p *testPKI) tlsConfig(clientAuth string) config.TLSConfig {
+	cfg := config.TLSConfig{
+		Enabled:    true,
+		CertFile:   p.path("server.pem"),
+		KeyFile:    p.path("server-key.pem"),
+		ClientAuth: clientAuth,
+	}
+
+	if clientAuth != config.ClientAuthNone {
+		cfg.ClientCAFile = p.path("ca.pem")
+	}
+
+	return cfg
+}
+
+// servidor gRPC com as credenciais do reloader e a mesma cadeia de identidade do server.go.
+// O canal recebe o certificado visto pelo handler em cada chamada
+func startTLSTestServer(t *testing.T, cfg config.TLSConfig) (string, <-chan *auth.ClientCertificate) {
+	t.Helper()
+
+	certificates, err := newCertificateReloader(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identities := make(chan *auth.ClientCertificate, 1)
+	capture := func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if cert, ok := auth.ClientCertificateFromContext(ctx); ok {
+			identities <- &cert
+		} else {
+			identities <- nil
+		}
+
+		return handler(ctx, req)
+	}
+
+	srv := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(certificates.serverTLSConfig())),
+		grpc.ChainUnaryInterceptor(interceptor.TLSIdentityUnaryServerInterceptor(), capture),
+	)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	listen, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go srv.Serve(listen)
+	t.Cleanup(srv.Stop)
+
+	return listen.Addr().String(), identities
+}
+
+func checkHealth(t *testing.T, addr string, clientTLS *tls.Config) error {
+	t.Helper()
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(clientTLS)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+
+	return err
+}
+
+func TestTLSHandshake(t *testing.T) {
+	pki := newTestPKI(t)
+	pki.writeServerCert(t, 2, time.Now())
+
+	addr, identities := startTLSTestServer(t, pki.tlsConfig(config.ClientAuthNone))
+
+	if err := checkHealth(t, addr, &tls.Config{RootCAs: pki.caPool, ServerName: "localhost"}); err != nil {
+		t.Fatalf("health check over TLS: %v", err)
+	}
+
+	if cert := <-identities; cert != nil {
+		t.Errorf("client certificate %+v in context without mTLS", cert)
+	}
+
+	// cliente que não confia na CA não completa o handshake
+	if err := checkHealth(t, addr, &tls.Config{RootCAs: x509.NewCertPool(), ServerName: "localhost"}); err == nil {
+		t.Error("handshake succeeded with an untrusted server certificate")
+	}
+}
+
+func TestRequireClientCertRejectsClientWithoutCertificate(t *testing.T) {
+	pki := newTestPKI(t)
+	pki.writeServerCert(t, 2, time.Now())
+
+	addr, identities := startTLSTestServer(t, pki.tlsConfig(config.ClientAuthRequire))
+
+	if err := checkHealth(t, addr, &tls.Config{RootCAs: pki.caPool, ServerName: "localhost"}); err == nil {
+		t.Fatal("call without a client certificate succeeded with client_auth=require")
+	}
+
+	select {
+	case <-identities:
+		t.Error("handler reached without a client certificate")
+	default:
+	}
+}
+
+func TestClientCertificateIdentityReachesContext(t *testing.T) {
+	pki := newTestPKI(t)
+	pki.writeServerCert(t, 2, time.Now())
+
+	addr, identities := startTLSTestServer(t, pki.tlsConfig(config.ClientAuthRequire))
+
+	clientTLS := &tls.Config{
+		RootCAs:      pki.caPool,
+		ServerName:   "localhost",
+		Certificates: []tls.Certificate{pki.clientCert(t)},
+	}
+
+	if err := checkHealth(t, addr, clientTLS); err != nil {
+		t.Fatalf("health check with client certificate: %v", err)
+	}
+
+	cert := <-identities
+	if cert == nil {
+		t.Fatal("no client certificate in context")
+	}
+
+	if cert.CommonName != "partner-a" || len(cert.DNSNames) != 1 || cert.DNSNames[0] != "partner-a.bank.test" {
+		t.Errorf("got CN %q and DNS SANs %v", cert.CommonName, cert.DNSNames)
+	}
+
+	if cert.Identity() != testClientSPIFFE || cert.SerialNumber != "100" {
+		t.Errorf("got identity %q and serial %v", cert.Identity(), cert.SerialNumber)
+	}
+}
+
+// serial do certificado que o servidor apresenta num handshake com a configuração atual
+func servedCertificateSerial(t *testing.T, r *certificateReloader, roots *x509.CertPool) int64 {
+	t.Helper()
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	go tls.Server(serverConn, r.serverTLSConfig()).Handshake()
+
+	client := tls.Client(clientConn, &tls.Config{RootCAs: roots, ServerName: "localhost"})
+	if err := client.Handshake(); err != nil {
+		t.Fatalf("handshake: %v", err)
+	}
+
+	return client.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+}
+
+func TestCertificateReloaderPicksUpRewrittenCertificate(t *testing.T) {
+	pki := newTestPKI(t)
+	pki.writeServerCert(t, 2, time.Now())
+
+	r, err := newCertificateReloader(pki.tlsConfig(config.ClientAuthNone))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if serial := servedCertificateSerial(t, r, pki.caPool); serial != 2 {
+		t.Fatalf("served serial %d, want 2", serial)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go r.watch(ctx, 10*time.Millisecond)
+
+	pki.writeServerCert(t, 3, time.Now().Add(time.Minute))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for servedCertificateSerial(t, r, pki.caPool) != 3 {
+		if time.Now().After(deadline) {
+			t.Fatal("rewritten certificate was not picked up")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,81 @@
+package ingest
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+// colunas obrigatórias, em qualquer ordem. notes é opcional
+var csvRequiredColumns = []string{"account_number", "transaction_type", "amount", "timestamp"}
+
+type CSVTransactionSource struct {
+	reader  *csv.Reader
+	columns map[string]int
+	row     int
+}
+
+func NewCSVTransactionSource(r io.Reader) (*CSVTransactionSource, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, col := range header {
+		columns[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+
+	for _, col := range csvRequiredColumns {
+		if _, ok := columns[col]; !ok {
+			return nil, fmt.Errorf("csv header %v is missing column %v", header, col)
+		}
+	}
+
+	return &CSVTransactionSource{reader: reader, columns: columns, row: 1}, nil
+}
+
+func (s *CSVTransactionSource) Next() (bank.ImportedTransaction, error) {
+	record, err := s.reader.Read()
+	s.row++
+
+	if errors.Is(err, io.EOF) {
+		return bank.ImportedTransaction{}, io.EOF
+	}
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return bank.ImportedTransaction{Row: s.row}, &bank.TransactionImportError{Row: s.row, Message: parseErr.Err.Error()}
+	}
+
+	if err != nil {
+		return bank.ImportedTransaction{}, fmt.Errorf("failed to read csv row %d: %w", s.row, err)
+	}
+
+	return parseTransactionFields(s.row,
+		s.field(record, "account_number"),
+		s.field(record, "transaction_type"),
+		s.field(record, "amount"),
+		s.field(record, "timestamp"),
+		s.field(record, "notes"),
+	)
+}
+
+// linhas com menos colunas que o cabeçalho deixam os campos faltantes vazios
+func (s *CSVTransactionSource) field(record []string, name string) string {
+	i, ok := s.columns[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+
+	return record[i]
+}
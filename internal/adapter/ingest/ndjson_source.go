@@ -0,0 +1,61 @@
+package ingest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+const maxNDJSONLineSize = 1 << 20
+
+type ndjsonTransaction struct {
+	AccountNumber   string          `json:"account_number"`
+	TransactionType string          `json:"transaction_type"`
+	Amount          json.RawMessage `json:"amount"`
+	Timestamp       string          `json:"timestamp"`
+	Notes           string          `json:"notes"`
+}
+
+// um objeto JSON por linha, com os mesmos campos do CSV. Linhas em branco são ignoradas
+type NDJSONTransactionSource struct {
+	scanner *bufio.Scanner
+	row     int
+}
+
+func NewNDJSONTransactionSource(r io.Reader) *NDJSONTransactionSource {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64<<10), maxNDJSONLineSize)
+
+	return &NDJSONTransactionSource{scanner: scanner}
+}
+
+func (s *NDJSONTransactionSource) Next() (bank.ImportedTransaction, error) {
+	for s.scanner.Scan() {
+		s.row++
+
+		line := strings.TrimSpace(s.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var t ndjsonTransaction
+		if err := json.Unmarshal([]byte(line), &t); err != nil {
+			return bank.ImportedTransaction{Row: s.row}, &bank.TransactionImportError{Row: s.row, Message: fmt.Sprintf("invalid json: %v", err)}
+		}
+
+		// amount aceito como número ou string
+		amount := strings.Trim(string(t.Amount), `"`)
+
+		return parseTransactionFields(s.row, t.AccountNumber, t.TransactionType, amount, t.Timestamp, t.Notes)
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		return bank.ImportedTransaction{}, fmt.Errorf("failed to read ndjson row %d: %w", s.row+1, err)
+	}
+
+	return bank.ImportedTransaction{}, io.EOF
+}
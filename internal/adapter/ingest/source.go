@@ -0,0 +1,70 @@
+package ingest
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+const (
+	FormatCSV    string = "csv"
+	FormatNDJSON string = "ndjson"
+)
+
+// formato pela extensão do arquivo, vazio quando não reconhecido
+func FormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON
+	}
+
+	return ""
+}
+
+func NewTransactionSource(format string, r io.Reader) (port.TransactionSourcePort, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return NewCSVTransactionSource(r)
+	case FormatNDJSON:
+		return NewNDJSONTransactionSource(r), nil
+	default:
+		return nil, fmt.Errorf("unknown transaction file format %q", format)
+	}
+}
+
+// converte os campos em texto de uma linha. Só erros de formato são tratados aqui,
+// as regras de negócio (conta existente, valor positivo...) ficam no service
+func parseTransactionFields(row int, accountNumber, transactionType, amount, timestamp, notes string) (bank.ImportedTransaction, error) {
+	t := bank.ImportedTransaction{
+		Row:           row,
+		AccountNumber: strings.TrimSpace(accountNumber),
+		Transaction: bank.Transaction{
+			TransactionType: strings.ToUpper(strings.TrimSpace(transactionType)),
+			Notes:           notes,
+		},
+	}
+
+	parsedAmount, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+	if err != nil {
+		return t, &bank.TransactionImportError{Row: row, Field: "amount", Message: fmt.Sprintf("invalid amount %q", amount)}
+	}
+
+	t.Amount = parsedAmount
+
+	ts, err := time.Parse(time.RFC3339, strings.TrimSpace(timestamp))
+	if err != nil {
+		return t, &bank.TransactionImportError{Row: row, Field: "timestamp", Message: fmt.Sprintf("invalid RFC3339 timestamp %q", timestamp)}
+	}
+
+	t.Timestamp = ts
+
+	return t, nil
+}
@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/webhook"
+)
+
+const DefaultWebhookTimeout = 10 * time.Second
+
+// envia as entregas assinadas com o secret da inscrição. Respostas fora de 2xx são falhas
+// e o status code volta para o log de tentativas
+type WebhookSender struct {
+	client   *http.Client
+	resolver *net.Resolver
+	allowed  func(netip.Addr) bool
+}
+
+func NewWebhookSender(timeout time.Duration) *WebhookSender {
+	return newWebhookSender(timeout, webhook.IsAllowedDestination)
+}
+
+func newWebhookSender(timeout time.Duration, allowed func(netip.Addr) bool) *WebhookSender {
+	// o Control roda com o IP já resolvido, imediatamente antes do connect: cobre DNS que passou
+	// a apontar para a rede interna depois da inscrição e hosts com vários registros
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("failed to parse dial address %v: %w", address, err)
+			}
+
+			if !allowed(addrPort.Addr()) {
+				return fmt.Errorf("%w: %v", webhook.ErrForbiddenDestination, addrPort.Addr())
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// proxy do ambiente faria o dial ir para o proxy e a checagem valeria para ele, não para o destino
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &WebhookSender{
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// redirect é tratado como resposta fora de 2xx, o parceiro cadastra a URL final
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		resolver: net.DefaultResolver,
+		allowed:  allowed,
+	}
+}
+
+func (s *WebhookSender) CheckDestination(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return webhook.ErrInvalidURL
+	}
+
+	addrs, err := s.resolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil {
+		return fmt.Errorf("%w: failed to resolve %v: %v", webhook.ErrInvalidURL, u.Hostname(), err)
+	}
+
+	// basta um registro interno para rejeitar, o dial pode escolher qualquer um deles
+	for _, addr := range addrs {
+		if !s.allowed(addr) {
+			return fmt.Errorf("%w: %v", webhook.ErrForbiddenDestination, addr)
+		}
+	}
+
+	return nil
+}
+
+func (s *WebhookSender) Send(ctx context.Context, url, secret string, delivery webhook.Delivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	// timestamp da tentativa e não do evento, para o receptor conseguir validar a janela de replay
+	now := time.Now()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(secret, now, delivery.Payload))
+	req.Header.Set(webhook.HeaderTimestamp, fmt.Sprintf("%d", now.Unix()))
+	req.Header.Set(webhook.HeaderEventID, delivery.EventUUID.String())
+	req.Header.Set(webhook.HeaderEventType, delivery.EventType)
+	req.Header.Set(webhook.HeaderDeliveryID, delivery.DeliveryUUID.String())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to post webhook delivery %v: %w", delivery.DeliveryUUID, err)
+	}
+	defer res.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return res.StatusCode, fmt.Errorf("webhook responded %v for delivery %v", res.Status, delivery.DeliveryUUID)
+	}
+
+	return res.StatusCode, nil
+}
@@ -0,0 +1,142 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/webhook"
+)
+
+// o receptor do teste escuta em 127.0.0.1, bloqueado pela política padrão
+func allowAll(netip.Addr) bool {
+	return true
+}
+
+func newTestDelivery() webhook.Delivery {
+	return webhook.Delivery{
+		DeliveryUUID: uuid.New(),
+		EventUUID:    uuid.New(),
+		EventType:    "TransactionCreated",
+		Payload:      []byte(`{"id":"1","type":"TransactionCreated"}`),
+	}
+}
+
+func TestSendSignsPayloadWithSubscriptionSecret(t *testing.T) {
+	const secret = "test-secret"
+
+	delivery := newTestDelivery()
+	received := make(chan *http.Request, 1)
+	var body []byte
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+	}))
+	defer srv.Close()
+
+	before := time.Now().Unix()
+
+	statusCode, err := newWebhookSender(time.Second, allowAll).Send(context.Background(), srv.URL, secret, delivery)
+	if err != nil || statusCode != http.StatusOK {
+		t.Fatalf("Send: %v %v", statusCode, err)
+	}
+
+	r := <-received
+
+	if string(body) != string(delivery.Payload) {
+		t.Errorf("body %s, want %s", body, delivery.Payload)
+	}
+
+	ts := r.Header.Get(webhook.HeaderTimestamp)
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || unix < before || unix > time.Now().Unix() {
+		t.Fatalf("timestamp header %q outside of the send window", ts)
+	}
+
+	// recalcula como o parceiro faria, sem usar webhook.Sign
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "."))
+	mac.Write(body)
+	want := "t=" + ts + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := r.Header.Get(webhook.HeaderSignature); !hmac.Equal([]byte(got), []byte(want)) {
+		t.Errorf("signature %q, want %q", got, want)
+	}
+
+	if r.Header.Get(webhook.HeaderDeliveryID) != delivery.DeliveryUUID.String() ||
+		r.Header.Get(webhook.HeaderEventID) != delivery.EventUUID.String() ||
+		r.Header.Get(webhook.HeaderEventType) != delivery.EventType {
+		t.Errorf("unexpected delivery headers: %v", r.Header)
+	}
+}
+
+func TestSendReportsNon2xxStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	statusCode, err := newWebhookSender(time.Second, allowAll).Send(context.Background(), srv.URL, "s", newTestDelivery())
+	if err == nil || statusCode != http.StatusFound {
+		t.Errorf("got %v %v, want the redirect reported as a failure", statusCode, err)
+	}
+}
+
+func TestSendRefusesLoopbackAtDialTime(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	_, err := NewWebhookSender(time.Second).Send(context.Background(), srv.URL, "s", newTestDelivery())
+	if !errors.Is(err, webhook.ErrForbiddenDestination) {
+		t.Errorf("got %v, want ErrForbiddenDestination", err)
+	}
+
+	if called {
+		t.Error("request reached a loopback receiver")
+	}
+}
+
+func TestCheckDestination(t *testing.T) {
+	sender := NewWebhookSender(time.Second)
+
+	forbidden := []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"https://10.1.2.3/hook",
+		"https://172.16.0.1/hook",
+		"https://192.168.1.10/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://100.64.0.1/hook",
+		"http://0.0.0.0/hook",
+		"http://[::1]/hook",
+		"http://[fe80::1]/hook",
+		"http://[fd00::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+	}
+
+	for _, rawURL := range forbidden {
+		if err := sender.CheckDestination(context.Background(), rawURL); !errors.Is(err, webhook.ErrForbiddenDestination) {
+			t.Errorf("%v: got %v, want ErrForbiddenDestination", rawURL, err)
+		}
+	}
+
+	for _, rawURL := range []string{"https://93.184.216.34/hook", "https://[2606:4700::1111]/hook"} {
+		if err := sender.CheckDestination(context.Background(), rawURL); err != nil {
+			t.Errorf("%v: %v", rawURL, err)
+		}
+	}
+}
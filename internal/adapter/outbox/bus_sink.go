@@ -0,0 +1,49 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+type EventHandler func(ctx context.Context, event bank.OutboxEvent) error
+
+// barramento em memória: entrega o evento para todos os handlers inscritos no tipo do evento
+// (ou em "*"). Qualquer handler com erro faz o relay tentar o evento de novo
+type EventBus struct {
+	mu       sync.RWMutex
+	handlers map[string][]EventHandler
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{handlers: make(map[string][]EventHandler)}
+}
+
+func (b *EventBus) Name() string {
+	return SinkBus
+}
+
+func (b *EventBus) Subscribe(eventType string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+func (b *EventBus) Publish(ctx context.Context, event bank.OutboxEvent) error {
+	b.mu.RLock()
+	handlers := append([]EventHandler{}, b.handlers[event.EventType]...)
+	handlers = append(handlers, b.handlers["*"]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, h := range handlers {
+		if err := h(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,70 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+type fileEnvelope struct {
+	Sequence         int64           `json:"sequence"`
+	EventUUID        uuid.UUID       `json:"event_uuid"`
+	AggregateType    string          `json:"aggregate_type"`
+	AggregateUUID    uuid.UUID       `json:"aggregate_uuid"`
+	AggregateVersion int64           `json:"aggregate_version"`
+	EventType        string          `json:"event_type"`
+	Payload          json.RawMessage `json:"payload"`
+	CreatedAt        time.Time       `json:"created_at"`
+}
+
+// acrescenta cada evento como uma linha JSON (NDJSON) no arquivo
+type FileSink struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+func (s *FileSink) Name() string {
+	return SinkFile
+}
+
+func (s *FileSink) Publish(ctx context.Context, event bank.OutboxEvent) error {
+	line, err := json.Marshal(fileEnvelope{
+		Sequence:         event.Sequence,
+		EventUUID:        event.EventUUID,
+		AggregateType:    event.AggregateType,
+		AggregateUUID:    event.AggregateUUID,
+		AggregateVersion: event.AggregateVersion,
+		EventType:        event.EventType,
+		Payload:          event.Payload,
+		CreatedAt:        event.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event %v: %w", event.EventUUID, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %v: %w", s.path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write event %v: %w", event.EventUUID, err)
+	}
+
+	// o relay só marca o evento como publicado depois que ele está em disco
+	return f.Sync()
+}
@@ -0,0 +1,44 @@
+package outbox
+
+import (
+	"fmt"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+const (
+	SinkBus     string = "bus"
+	SinkWebhook string = "webhook"
+	SinkFile    string = "file"
+)
+
+type SinkConfig struct {
+	Sink   string
+	Target string
+	Bus    *EventBus
+}
+
+func NewOutboxSink(cfg SinkConfig) (port.OutboxSinkPort, error) {
+	switch cfg.Sink {
+	case SinkBus, "":
+		if cfg.Bus == nil {
+			return NewEventBus(), nil
+		}
+
+		return cfg.Bus, nil
+	case SinkWebhook:
+		if cfg.Target == "" {
+			return nil, fmt.Errorf("webhook sink requires a target url")
+		}
+
+		return NewWebhookSink(cfg.Target), nil
+	case SinkFile:
+		if cfg.Target == "" {
+			return nil, fmt.Errorf("file sink requires a target path")
+		}
+
+		return NewFileSink(cfg.Target), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox sink %q", cfg.Sink)
+	}
+}
@@ -0,0 +1,59 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+// envia o payload do evento via POST, qualquer resposta fora de 2xx é tratada como falha
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *WebhookSink) Name() string {
+	return SinkWebhook
+}
+
+func (s *WebhookSink) Publish(ctx context.Context, event bank.OutboxEvent) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(event.Payload))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Id", event.EventUUID.String())
+	req.Header.Set("X-Event-Type", event.EventType)
+	req.Header.Set("X-Event-Sequence", strconv.FormatInt(event.Sequence, 10))
+	req.Header.Set("X-Aggregate-Type", event.AggregateType)
+	req.Header.Set("X-Aggregate-Id", event.AggregateUUID.String())
+	req.Header.Set("X-Aggregate-Version", strconv.FormatInt(event.AggregateVersion, 10))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post event %v: %w", event.EventUUID, err)
+	}
+	defer res.Body.Close()
+
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %v for event %v", res.Status, event.EventUUID)
+	}
+
+	return nil
+}
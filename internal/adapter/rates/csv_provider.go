@@ -0,0 +1,113 @@
+package rates
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+var csvHeader = []string{"from_currency", "to_currency", "rate", "valid_from", "valid_to"}
+
+// arquivo CSV com cabeçalho from_currency,to_currency,rate,valid_from,valid_to e timestamps RFC3339
+type CSVProvider struct {
+	path         string
+	lastModified time.Time
+}
+
+func NewCSVProvider(path string) *CSVProvider {
+	return &CSVProvider{path: path}
+}
+
+func (p *CSVProvider) Name() string {
+	return ProviderCSV
+}
+
+// o arquivo só é reimportado quando for modificado
+func (p *CSVProvider) FetchExchangeRates(ctx context.Context) ([]bank.ExchangeRate, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %v: %w", p.path, err)
+	}
+
+	if !info.ModTime().After(p.lastModified) {
+		return nil, nil
+	}
+
+	r, err := openSource(ctx, p.path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	res, err := parseCSVRates(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p.lastModified = info.ModTime()
+
+	return res, nil
+}
+
+func parseCSVRates(r io.Reader) ([]bank.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = len(csvHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	for i, col := range csvHeader {
+		if strings.ToLower(header[i]) != col {
+			return nil, fmt.Errorf("unexpected csv header %v, expected %v", header, csvHeader)
+		}
+	}
+
+	var res []bank.ExchangeRate
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv line %d: %w", line, err)
+		}
+
+		rate, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate on csv line %d: %w", line, err)
+		}
+
+		validFrom, err := time.Parse(time.RFC3339, record[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid valid_from on csv line %d: %w", line, err)
+		}
+
+		validTo, err := time.Parse(time.RFC3339, record[4])
+		if err != nil {
+			return nil, fmt.Errorf("invalid valid_to on csv line %d: %w", line, err)
+		}
+
+		res = append(res, bank.ExchangeRate{
+			FromCurrency:       strings.ToUpper(record[0]),
+			ToCurrency:         strings.ToUpper(record[1]),
+			Rate:               rate,
+			ValidFromTimestamp: validFrom,
+			ValidToTimestamp:   validTo,
+		})
+	}
+
+	return res, nil
+}
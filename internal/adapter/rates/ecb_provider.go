@@ -0,0 +1,95 @@
+package rates
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+const ECBDailyURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// formato eurofxref do BCE: <Cube><Cube time="..."><Cube currency="USD" rate="1.09"/></Cube></Cube>,
+// todas as taxas são cotadas a partir do EUR
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+type ECBProvider struct {
+	source   string
+	lastDate time.Time
+}
+
+func NewECBProvider(source string) *ECBProvider {
+	if source == "" {
+		source = ECBDailyURL
+	}
+
+	return &ECBProvider{source: source}
+}
+
+func (p *ECBProvider) Name() string {
+	return ProviderECB
+}
+
+// retorna apenas os dias ainda não importados, assim o arquivo pode ser relido a cada intervalo
+func (p *ECBProvider) FetchExchangeRates(ctx context.Context) ([]bank.ExchangeRate, error) {
+	r, err := openSource(ctx, p.source)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode ECB xml: %w", err)
+	}
+
+	var res []bank.ExchangeRate
+	lastDate := p.lastDate
+
+	for _, day := range envelope.Days {
+		validFrom, err := time.Parse(time.DateOnly, day.Time)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECB date %q: %w", day.Time, err)
+		}
+
+		if !validFrom.After(p.lastDate) {
+			continue
+		}
+
+		validTo := validFrom.AddDate(0, 0, 1).Add(-1 * time.Millisecond)
+
+		for _, cube := range day.Rates {
+			rate, err := strconv.ParseFloat(cube.Rate, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ECB rate %q for %v: %w", cube.Rate, cube.Currency, err)
+			}
+
+			res = append(res, bank.ExchangeRate{
+				FromCurrency:       "EUR",
+				ToCurrency:         cube.Currency,
+				Rate:               rate,
+				ValidFromTimestamp: validFrom,
+				ValidToTimestamp:   validTo,
+			})
+		}
+
+		if validFrom.After(lastDate) {
+			lastDate = validFrom
+		}
+	}
+
+	p.lastDate = lastDate
+
+	return res, nil
+}
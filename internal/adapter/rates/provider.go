@@ -0,0 +1,72 @@
+package rates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+const (
+	ProviderSimulator string = "simulator"
+	ProviderECB       string = "ecb"
+	ProviderCSV       string = "csv"
+)
+
+type ProviderConfig struct {
+	Provider  string
+	Source    string
+	Simulator SimulatorConfig
+}
+
+func NewExchangeRateProvider(cfg ProviderConfig) (port.ExchangeRateProviderPort, error) {
+	switch cfg.Provider {
+	case ProviderSimulator, "":
+		return NewSimulatorProvider(cfg.Simulator)
+	case ProviderECB:
+		return NewECBProvider(cfg.Source), nil
+	case ProviderCSV:
+		return NewCSVProvider(cfg.Source), nil
+	default:
+		return nil, fmt.Errorf("unknown exchange rate provider %q", cfg.Provider)
+	}
+}
+
+// limite de uma busca http. O ctx do importer também cancela a requisição no shutdown
+const fetchTimeout = 30 * time.Second
+
+var httpClient = &http.Client{Timeout: fetchTimeout}
+
+// abre um arquivo local ou uma URL http(s)
+func openSource(ctx context.Context, source string) (io.ReadCloser, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source %v: %w", source, err)
+		}
+
+		res, err := httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %v: %w", source, err)
+		}
+
+		if res.StatusCode != http.StatusOK {
+			res.Body.Close()
+			return nil, fmt.Errorf("failed to fetch %v: status %v", source, res.Status)
+		}
+
+		return res.Body, nil
+	}
+
+	f, err := os.Open(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %v: %w", source, err)
+	}
+
+	return f, nil
+}
@@ -0,0 +1,169 @@
+package rates
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+const (
+	ecbSampleFile = "../../../db/rates/eurofxref-sample.xml"
+	csvSampleFile = "../../../db/rates/rates-sample.csv"
+)
+
+func TestECBProviderParsesSample(t *testing.T) {
+	p := NewECBProvider(ecbSampleFile)
+
+	rates, err := p.FetchExchangeRates(context.Background())
+	if err != nil {
+		t.Fatalf("FetchExchangeRates: %v", err)
+	}
+
+	if len(rates) != 4 {
+		t.Fatalf("got %d rates, want 4", len(rates))
+	}
+
+	validFrom := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	want := map[string]float64{"USD": 1.0305, "JPY": 161.79, "GBP": 0.8422, "BRL": 6.2486}
+
+	for _, r := range rates {
+		if r.FromCurrency != "EUR" {
+			t.Errorf("%v: from currency %v, want EUR", r.ToCurrency, r.FromCurrency)
+		}
+
+		if r.Rate != want[r.ToCurrency] {
+			t.Errorf("%v: rate %v, want %v", r.ToCurrency, r.Rate, want[r.ToCurrency])
+		}
+
+		if !r.ValidFromTimestamp.Equal(validFrom) {
+			t.Errorf("%v: valid from %v, want %v", r.ToCurrency, r.ValidFromTimestamp, validFrom)
+		}
+
+		if !r.ValidToTimestamp.After(r.ValidFromTimestamp) || !r.ValidToTimestamp.Before(validFrom.AddDate(0, 0, 1)) {
+			t.Errorf("%v: valid to %v outside of the day", r.ToCurrency, r.ValidToTimestamp)
+		}
+	}
+
+	// o mesmo dia não é importado de novo
+	rates, err = p.FetchExchangeRates(context.Background())
+	if err != nil {
+		t.Fatalf("second FetchExchangeRates: %v", err)
+	}
+
+	if len(rates) != 0 {
+		t.Errorf("second fetch returned %d rates, want 0", len(rates))
+	}
+}
+
+func TestECBProviderFetchesOverHTTP(t *testing.T) {
+	sample, err := os.ReadFile(ecbSampleFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(sample)
+	}))
+	defer srv.Close()
+
+	rates, err := NewECBProvider(srv.URL).FetchExchangeRates(context.Background())
+	if err != nil {
+		t.Fatalf("FetchExchangeRates: %v", err)
+	}
+
+	if len(rates) != 4 {
+		t.Errorf("got %d rates, want 4", len(rates))
+	}
+}
+
+func TestECBProviderStopsOnCancelledContext(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := NewECBProvider(srv.URL).FetchExchangeRates(ctx)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v, want context.DeadlineExceeded", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("fetch did not stop after the context was cancelled")
+	}
+}
+
+func TestCSVProviderParsesSample(t *testing.T) {
+	p := NewCSVProvider(csvSampleFile)
+
+	rates, err := p.FetchExchangeRates(context.Background())
+	if err != nil {
+		t.Fatalf("FetchExchangeRates: %v", err)
+	}
+
+	want := []bank.ExchangeRate{
+		{FromCurrency: "USD", ToCurrency: "BRL", Rate: 6.0612},
+		{FromCurrency: "USD", ToCurrency: "EUR", Rate: 0.9704},
+		{FromCurrency: "EUR", ToCurrency: "BRL", Rate: 6.2486},
+	}
+
+	if len(rates) != len(want) {
+		t.Fatalf("got %d rates, want %d", len(rates), len(want))
+	}
+
+	validFrom := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	validTo := time.Date(2025, 1, 15, 23, 59, 59, 0, time.UTC)
+
+	for i, r := range rates {
+		if r.FromCurrency != want[i].FromCurrency || r.ToCurrency != want[i].ToCurrency || r.Rate != want[i].Rate {
+			t.Errorf("rate %d: got %v %v %v, want %v %v %v", i, r.FromCurrency, r.ToCurrency, r.Rate,
+				want[i].FromCurrency, want[i].ToCurrency, want[i].Rate)
+		}
+
+		if !r.ValidFromTimestamp.Equal(validFrom) || !r.ValidToTimestamp.Equal(validTo) {
+			t.Errorf("rate %d: validity %v - %v, want %v - %v", i, r.ValidFromTimestamp, r.ValidToTimestamp, validFrom, validTo)
+		}
+	}
+
+	// sem modificação o arquivo não é relido
+	rates, err = p.FetchExchangeRates(context.Background())
+	if err != nil {
+		t.Fatalf("second FetchExchangeRates: %v", err)
+	}
+
+	if rates != nil {
+		t.Errorf("second fetch returned %d rates, want none", len(rates))
+	}
+}
+
+func TestParseCSVRatesRejectsInvalidInput(t *testing.T) {
+	tests := map[string]string{
+		"header":     "from,to,rate,valid_from,valid_to\n",
+		"rate":       "from_currency,to_currency,rate,valid_from,valid_to\nUSD,BRL,abc,2025-01-15T00:00:00Z,2025-01-15T23:59:59Z\n",
+		"valid_from": "from_currency,to_currency,rate,valid_from,valid_to\nUSD,BRL,6.1,2025-01-15,2025-01-15T23:59:59Z\n",
+		"columns":    "from_currency,to_currency,rate,valid_from,valid_to\nUSD,BRL,6.1\n",
+	}
+
+	for name, input := range tests {
+		if _, err := parseCSVRates(strings.NewReader(input)); err == nil {
+			t.Errorf("%v: expected an error", name)
+		}
+	}
+}
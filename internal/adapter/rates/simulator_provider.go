@@ -0,0 +1,199 @@
+package rates
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+const (
+	ModelRandomWalk    string = "random-walk"
+	ModelMeanReverting string = "mean-reverting"
+)
+
+type SimulatedPair struct {
+	FromCurrency string
+	ToCurrency   string
+	InitialRate  float64
+}
+
+// Volatility é o desvio padrão do log-retorno a cada passo e MeanReversion a fração
+// da distância até a taxa inicial que é recuperada a cada passo (apenas no modelo mean-reverting)
+type SimulatorConfig struct {
+	Pairs         []SimulatedPair
+	Model         string
+	Volatility    float64
+	MeanReversion float64
+	Step          time.Duration
+	Seed          int64
+	Start         time.Time
+}
+
+// pending é o próximo passo já sorteado e ainda não confirmado. Enquanto a taxa não for gravada,
+// as buscas devolvem o mesmo passo, então uma escrita rejeitada não consome a sequência do seed
+type simulatedRate struct {
+	pair           SimulatedPair
+	logRate        float64
+	validFrom      time.Time
+	pending        *bank.ExchangeRate
+	pendingLogRate float64
+}
+
+// simulador de taxas para desenvolvimento. Cada passo confirmado gera a próxima janela de validade
+// contígua: [start, start+step-1ms], [start+step, start+2*step-1ms], ... com start na próxima fronteira
+// de step. Como o importer busca na partida e a cada step, cada janela é gravada antes de começar
+type SimulatorProvider struct {
+	cfg   SimulatorConfig
+	rnd   *rand.Rand
+	rates []simulatedRate
+}
+
+func NewSimulatorProvider(cfg SimulatorConfig) (*SimulatorProvider, error) {
+	if len(cfg.Pairs) == 0 {
+		return nil, fmt.Errorf("simulator requires at least one currency pair")
+	}
+
+	if cfg.Model == "" {
+		cfg.Model = ModelRandomWalk
+	}
+
+	if cfg.Model != ModelRandomWalk && cfg.Model != ModelMeanReverting {
+		return nil, fmt.Errorf("unknown simulator model %q", cfg.Model)
+	}
+
+	if cfg.Volatility < 0 || cfg.MeanReversion < 0 || cfg.MeanReversion > 1 {
+		return nil, fmt.Errorf("invalid simulator parameters: volatility %v, mean reversion %v", cfg.Volatility, cfg.MeanReversion)
+	}
+
+	if cfg.Step <= 0 {
+		return nil, fmt.Errorf("simulator step must be positive")
+	}
+
+	if cfg.Start.IsZero() {
+		cfg.Start = time.Now()
+	}
+
+	start := cfg.Start.Truncate(cfg.Step)
+	if start.Before(cfg.Start) {
+		start = start.Add(cfg.Step)
+	}
+
+	p := &SimulatorProvider{
+		cfg: cfg,
+		rnd: rand.New(rand.NewSource(cfg.Seed)),
+	}
+
+	for _, pair := range cfg.Pairs {
+		if pair.InitialRate <= 0 {
+			return nil, fmt.Errorf("invalid initial rate %v for %v/%v", pair.InitialRate, pair.FromCurrency, pair.ToCurrency)
+		}
+
+		p.rates = append(p.rates, simulatedRate{
+			pair:      pair,
+			logRate:   math.Log(pair.InitialRate),
+			validFrom: start,
+		})
+	}
+
+	return p, nil
+}
+
+func (p *SimulatorProvider) Name() string {
+	return ProviderSimulator
+}
+
+func (p *SimulatorProvider) FetchExchangeRates(ctx context.Context) ([]bank.ExchangeRate, error) {
+	res := make([]bank.ExchangeRate, 0, len(p.rates))
+
+	for i := range p.rates {
+		r := &p.rates[i]
+
+		if r.pending == nil {
+			p.step(r)
+		}
+
+		res = append(res, *r.pending)
+	}
+
+	return res, nil
+}
+
+// avança os pares cujas taxas foram gravadas. Os demais repetem o mesmo passo na próxima busca
+func (p *SimulatorProvider) AckExchangeRates(rates []bank.ExchangeRate) {
+	for _, rate := range rates {
+		for i := range p.rates {
+			r := &p.rates[i]
+			if r.pending == nil || r.pair.FromCurrency != rate.FromCurrency || r.pair.ToCurrency != rate.ToCurrency ||
+				!r.pending.ValidFromTimestamp.Equal(rate.ValidFromTimestamp) {
+				continue
+			}
+
+			r.logRate = r.pendingLogRate
+			r.validFrom = r.validFrom.Add(p.cfg.Step)
+			r.pending = nil
+		}
+	}
+}
+
+func (p *SimulatorProvider) step(r *simulatedRate) {
+	shock := p.cfg.Volatility * p.rnd.NormFloat64()
+	logRate := r.logRate
+
+	switch p.cfg.Model {
+	case ModelRandomWalk:
+		logRate += shock
+	case ModelMeanReverting:
+		// Ornstein-Uhlenbeck discreto no log da taxa, puxando de volta para a taxa inicial
+		logRate += p.cfg.MeanReversion*(math.Log(r.pair.InitialRate)-logRate) + shock
+	}
+
+	r.pendingLogRate = logRate
+	r.pending = &bank.ExchangeRate{
+		FromCurrency:       r.pair.FromCurrency,
+		ToCurrency:         r.pair.ToCurrency,
+		Rate:               math.Round(math.Exp(logRate)*1e6) / 1e6,
+		ValidFromTimestamp: r.validFrom,
+		ValidToTimestamp:   r.validFrom.Add(p.cfg.Step).Add(-1 * time.Millisecond),
+	}
+}
+
+// lê pares no formato "USD/BRL=5.80,USD/EUR=0.92"
+func ParseSimulatedPairs(s string) ([]SimulatedPair, error) {
+	var res []SimulatedPair
+
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		pair, initial, found := strings.Cut(item, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid simulated pair %q, expected FROM/TO=rate", item)
+		}
+
+		from, to, found := strings.Cut(pair, "/")
+		if !found || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid simulated pair %q, expected FROM/TO=rate", item)
+		}
+
+		rate, err := strconv.ParseFloat(initial, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid initial rate in simulated pair %q: %w", item, err)
+		}
+
+		res = append(res, SimulatedPair{
+			FromCurrency: strings.ToUpper(strings.TrimSpace(from)),
+			ToCurrency:   strings.ToUpper(strings.TrimSpace(to)),
+			InitialRate:  rate,
+		})
+	}
+
+	return res, nil
+}
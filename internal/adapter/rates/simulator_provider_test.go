@@ -0,0 +1,155 @@
+package rates
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+func newTestSimulator(t *testing.T, seed int64, start time.Time) *SimulatorProvider {
+	t.Helper()
+
+	p, err := NewSimulatorProvider(SimulatorConfig{
+		Pairs: []SimulatedPair{
+			{FromCurrency: "USD", ToCurrency: "BRL", InitialRate: 5.8},
+			{FromCurrency: "USD", ToCurrency: "EUR", InitialRate: 0.92},
+		},
+		Model:         ModelMeanReverting,
+		Volatility:    0.01,
+		MeanReversion: 0.1,
+		Step:          time.Minute,
+		Seed:          seed,
+		Start:         start,
+	})
+	if err != nil {
+		t.Fatalf("NewSimulatorProvider: %v", err)
+	}
+
+	return p
+}
+
+// busca e confirma todas as taxas, como o importer faz quando todas são gravadas
+func fetchAndAck(t *testing.T, p *SimulatorProvider) []bank.ExchangeRate {
+	t.Helper()
+
+	rates, err := p.FetchExchangeRates(context.Background())
+	if err != nil {
+		t.Fatalf("FetchExchangeRates: %v", err)
+	}
+
+	p.AckExchangeRates(rates)
+
+	return rates
+}
+
+func TestSimulatorSameSeedSameSequence(t *testing.T) {
+	start := time.Date(2025, 1, 15, 10, 0, 30, 0, time.UTC)
+	a := newTestSimulator(t, 42, start)
+	b := newTestSimulator(t, 42, start)
+	c := newTestSimulator(t, 43, start)
+
+	differs := false
+
+	for step := 0; step < 20; step++ {
+		ra, rb, rc := fetchAndAck(t, a), fetchAndAck(t, b), fetchAndAck(t, c)
+
+		for i := range ra {
+			if ra[i] != rb[i] {
+				t.Fatalf("step %d pair %d: %+v != %+v with the same seed", step, i, ra[i], rb[i])
+			}
+
+			if ra[i].Rate != rc[i].Rate {
+				differs = true
+			}
+		}
+	}
+
+	if !differs {
+		t.Error("different seeds produced the same sequence")
+	}
+}
+
+func TestSimulatorStartsAtNextStepBoundary(t *testing.T) {
+	start := time.Date(2025, 1, 15, 10, 0, 30, 0, time.UTC)
+	p := newTestSimulator(t, 1, start)
+
+	first := fetchAndAck(t, p)
+	second := fetchAndAck(t, p)
+
+	wantFrom := time.Date(2025, 1, 15, 10, 1, 0, 0, time.UTC)
+	wantTo := wantFrom.Add(time.Minute - time.Millisecond)
+
+	for _, r := range first {
+		if !r.ValidFromTimestamp.Equal(wantFrom) || !r.ValidToTimestamp.Equal(wantTo) {
+			t.Errorf("first window %v - %v, want %v - %v", r.ValidFromTimestamp, r.ValidToTimestamp, wantFrom, wantTo)
+		}
+
+		if r.ValidToTimestamp.Before(start) {
+			t.Errorf("first window already expired at start: %v", r.ValidToTimestamp)
+		}
+	}
+
+	for _, r := range second {
+		if !r.ValidFromTimestamp.Equal(wantFrom.Add(time.Minute)) {
+			t.Errorf("second window starts at %v, want %v", r.ValidFromTimestamp, wantFrom.Add(time.Minute))
+		}
+	}
+}
+
+func TestSimulatorStartOnBoundaryIsKept(t *testing.T) {
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	p := newTestSimulator(t, 1, start)
+
+	for _, r := range fetchAndAck(t, p) {
+		if !r.ValidFromTimestamp.Equal(start) {
+			t.Errorf("window starts at %v, want %v", r.ValidFromTimestamp, start)
+		}
+	}
+}
+
+func TestSimulatorRejectedRateIsNotAdvanced(t *testing.T) {
+	start := time.Date(2025, 1, 15, 10, 0, 30, 0, time.UTC)
+	p := newTestSimulator(t, 7, start)
+	reference := newTestSimulator(t, 7, start)
+
+	rates, err := p.FetchExchangeRates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// só o primeiro par foi gravado
+	p.AckExchangeRates(rates[:1])
+
+	retry, err := p.FetchExchangeRates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if retry[1] != rates[1] {
+		t.Errorf("rejected rate changed on retry: %+v, want %+v", retry[1], rates[1])
+	}
+
+	if !retry[0].ValidFromTimestamp.Equal(rates[0].ValidFromTimestamp.Add(time.Minute)) {
+		t.Errorf("accepted pair did not advance: %v", retry[0].ValidFromTimestamp)
+	}
+
+	// sem ack nada avança
+	again, err := p.FetchExchangeRates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range again {
+		if again[i] != retry[i] {
+			t.Errorf("pair %d changed without ack: %+v, want %+v", i, again[i], retry[i])
+		}
+	}
+
+	// a rejeição não consome a sequência do seed: o par rejeitado recebe o mesmo valor do simulador de referência
+	ref := fetchAndAck(t, reference)
+	if rates[1] != ref[1] {
+		t.Errorf("pending rate %+v, want %+v", rates[1], ref[1])
+	}
+}
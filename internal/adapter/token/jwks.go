@@ -0,0 +1,89 @@
+package token
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// chave no formato JWK. Só RSA (kty RSA) e segredos HMAC (kty oct) são suportados
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type keySet struct {
+	rsa  map[string]*rsa.PublicKey
+	hmac map[string][]byte
+}
+
+func loadKeySet(path string) (keySet, error) {
+	ks := keySet{
+		rsa:  make(map[string]*rsa.PublicKey),
+		hmac: make(map[string][]byte),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ks, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return ks, fmt.Errorf("failed to parse JWKS file %v: %w", path, err)
+	}
+
+	for i, k := range set.Keys {
+		// chaves de criptografia não servem para verificar assinaturas
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		switch k.Kty {
+		case "RSA":
+			key, err := parseRSAKey(k)
+			if err != nil {
+				return ks, fmt.Errorf("failed to parse JWKS key %d (kid %q): %w", i, k.Kid, err)
+			}
+
+			ks.rsa[k.Kid] = key
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil || len(secret) == 0 {
+				return ks, fmt.Errorf("failed to parse JWKS key %d (kid %q): invalid k", i, k.Kid)
+			}
+
+			ks.hmac[k.Kid] = secret
+		}
+	}
+
+	return ks, nil
+}
+
+func parseRSAKey(k jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil || len(n) == 0 {
+		return nil, fmt.Errorf("invalid modulus")
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil || len(e) == 0 || len(e) > 4 {
+		return nil, fmt.Errorf("invalid exponent")
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
@@ -0,0 +1,216 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+	"github.com/viquitorreis/my-grpc-go-server/internal/logging"
+)
+
+var logger = logging.Logger("token")
+
+const (
+	rolesClaim        = "roles"
+	accountsClaim     = "accounts"
+	customerTierClaim = "customer_tier"
+)
+
+var (
+	ErrTokenKeyNotFound    = errors.New("no verification key for token")
+	ErrTokenNoSubject      = errors.New("token has no subject")
+	ErrTokenIssuerRequired = errors.New("jwt issuer and audience are required")
+)
+
+// valida JWTs HS256 (segredo da configuração ou chave oct do JWKS) e RS256 (chaves RSA do JWKS).
+// Um kid desconhecido recarrega o JWKS se o arquivo mudou, então chaves novas não exigem restart
+type JWTVerifier struct {
+	cfg    config.JWTConfig
+	secret []byte
+	parser *jwt.Parser
+
+	mu          sync.RWMutex
+	keys        keySet
+	keysModTime time.Time
+}
+
+func NewJWTVerifier(cfg config.JWTConfig) (*JWTVerifier, error) {
+	// sem iss e aud qualquer token assinado pela mesma chave seria aceito
+	if cfg.Issuer == "" || cfg.Audience == "" {
+		return nil, ErrTokenIssuerRequired
+	}
+
+	v := &JWTVerifier{cfg: cfg}
+
+	if cfg.HMACSecret != "" {
+		v.secret = []byte(cfg.HMACSecret)
+	}
+
+	if cfg.JWKSFile != "" {
+		if err := v.loadKeys(); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.Leeway),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithAudience(cfg.Audience),
+	}
+
+	v.parser = jwt.NewParser(opts...)
+
+	return v, nil
+}
+
+func (v *JWTVerifier) Verify(token string) (auth.Principal, error) {
+	claims := jwt.MapClaims{}
+
+	if _, err := v.parser.ParseWithClaims(token, claims, v.key); err != nil {
+		return auth.Principal{}, err
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil {
+		return auth.Principal{}, err
+	}
+
+	if subject == "" {
+		return auth.Principal{}, ErrTokenNoSubject
+	}
+
+	return auth.Principal{
+		Subject:  subject,
+		Method:   auth.MethodJWT,
+		Roles:    claimStrings(claims, rolesClaim),
+		Accounts: claimStrings(claims, accountsClaim),
+		Tier:     claimString(claims, customerTierClaim),
+		Claims:   claims,
+	}, nil
+}
+
+// aceita tanto uma lista JSON quanto uma string separada por espaços, como no claim scope do OAuth
+func claimStrings(claims jwt.MapClaims, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return strings.Fields(v)
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				values = append(values, str)
+			}
+		}
+
+		return values
+	}
+
+	return nil
+}
+
+func claimString(claims jwt.MapClaims, name string) string {
+	v, _ := claims[name].(string)
+	return strings.TrimSpace(v)
+}
+
+func (v *JWTVerifier) loadKeys() error {
+	info, err := os.Stat(v.cfg.JWKSFile)
+	if err != nil {
+		return fmt.Errorf("failed to stat JWKS file: %w", err)
+	}
+
+	keys, err := loadKeySet(v.cfg.JWKSFile)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	v.keys = keys
+	v.keysModTime = info.ModTime()
+	v.mu.Unlock()
+
+	return nil
+}
+
+// recarrega o JWKS só quando o arquivo mudou desde a última leitura. Uma recarga com erro mantém as chaves anteriores
+func (v *JWTVerifier) refreshKeys() bool {
+	info, err := os.Stat(v.cfg.JWKSFile)
+	if err != nil {
+		return false
+	}
+
+	v.mu.RLock()
+	unchanged := info.ModTime().Equal(v.keysModTime)
+	v.mu.RUnlock()
+
+	if unchanged {
+		return false
+	}
+
+	if err := v.loadKeys(); err != nil {
+		logger.Error("failed to reload JWKS file, keeping the previous keys", "err", err)
+		return false
+	}
+
+	logger.Info("JWKS file reloaded", "jwks_file", v.cfg.JWKSFile)
+
+	return true
+}
+
+// escolhe a chave pelo kid do header. Sem kid, usa o segredo configurado ou a única chave do tipo no JWKS
+func (v *JWTVerifier) key(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	if key, ok := v.lookupKey(t.Method.Alg(), kid); ok {
+		return key, nil
+	}
+
+	// kid novo: a chave pode ter sido adicionada ao arquivo depois da última leitura
+	if kid != "" && v.cfg.JWKSFile != "" && v.refreshKeys() {
+		if key, ok := v.lookupKey(t.Method.Alg(), kid); ok {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: alg %v, kid %q", ErrTokenKeyNotFound, t.Method.Alg(), kid)
+}
+
+func (v *JWTVerifier) lookupKey(alg, kid string) (any, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	switch alg {
+	case jwt.SigningMethodHS256.Alg():
+		if kid != "" {
+			if secret, ok := v.keys.hmac[kid]; ok {
+				return secret, true
+			}
+		} else if v.secret != nil {
+			return v.secret, true
+		} else if len(v.keys.hmac) == 1 {
+			for _, secret := range v.keys.hmac {
+				return secret, true
+			}
+		}
+	case jwt.SigningMethodRS256.Alg():
+		if kid != "" {
+			if key, ok := v.keys.rsa[kid]; ok {
+				return key, true
+			}
+		} else if len(v.keys.rsa) == 1 {
+			for _, key := range v.keys.rsa {
+				return key, true
+			}
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,268 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+)
+
+const (
+	testIssuer   = "https://auth.test"
+	testAudience = "bank"
+	testSecret   = "test-hmac-secret"
+)
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":      "customer-1",
+		"iss":      testIssuer,
+		"aud":      testAudience,
+		"exp":      time.Now().Add(time.Hour).Unix(),
+		"roles":    "customer",
+		"accounts": []any{"ACC-1", "ACC-2"},
+	}
+}
+
+func signHS256(t *testing.T, claims jwt.MapClaims, kid string, secret []byte) string {
+	t.Helper()
+
+	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+
+	s, err := tok.SignedString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func signRS256(t *testing.T, claims jwt.MapClaims, kid string, key *rsa.PrivateKey) string {
+	t.Helper()
+
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = kid
+
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) jsonWebKey {
+	return jsonWebKey{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func writeJWKS(t *testing.T, path string, keys ...jsonWebKey) {
+	t.Helper()
+
+	data, err := json.Marshal(jsonWebKeySet{Keys: keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func TestJWTVerifier(t *testing.T) {
+	rsaKey := newTestRSAKey(t)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, jwksFile, rsaJWK("rsa-1", &rsaKey.PublicKey),
+		jsonWebKey{Kty: "oct", Kid: "oct-1", K: base64.RawURLEncoding.EncodeToString([]byte("oct-secret"))})
+
+	v, err := NewJWTVerifier(config.JWTConfig{
+		HMACSecret: testSecret,
+		JWKSFile:   jwksFile,
+		Issuer:     testIssuer,
+		Audience:   testAudience,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	with := func(key string, value any) jwt.MapClaims {
+		c := testClaims()
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "hs256 with configured secret", token: signHS256(t, testClaims(), "", []byte(testSecret))},
+		{name: "hs256 with jwks oct key", token: signHS256(t, testClaims(), "oct-1", []byte("oct-secret"))},
+		{name: "rs256 with jwks rsa key", token: signRS256(t, testClaims(), "rsa-1", rsaKey)},
+		{name: "wrong secret", token: signHS256(t, testClaims(), "", []byte("other")), wantErr: jwt.ErrTokenSignatureInvalid},
+		{name: "unknown kid", token: signRS256(t, testClaims(), "rsa-2", rsaKey), wantErr: ErrTokenKeyNotFound},
+		{name: "expired", token: signHS256(t, with("exp", time.Now().Add(-time.Hour).Unix()), "", []byte(testSecret)),
+			wantErr: jwt.ErrTokenExpired},
+		{name: "missing exp", token: signHS256(t, with("exp", nil), "", []byte(testSecret)),
+			wantErr: jwt.ErrTokenRequiredClaimMissing},
+		{name: "wrong audience", token: signHS256(t, with("aud", "other"), "", []byte(testSecret)),
+			wantErr: jwt.ErrTokenInvalidAudience},
+		{name: "wrong issuer", token: signHS256(t, with("iss", "https://evil.test"), "", []byte(testSecret)),
+			wantErr: jwt.ErrTokenInvalidIssuer},
+		{name: "missing subject", token: signHS256(t, with("sub", nil), "", []byte(testSecret)), wantErr: ErrTokenNoSubject},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			principal, err := v.Verify(tc.token)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("got %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Verify: %v", err)
+			}
+
+			if principal.Subject != "customer-1" || !slices.Equal(principal.Roles, []string{"customer"}) ||
+				!slices.Equal(principal.Accounts, []string{"ACC-1", "ACC-2"}) {
+				t.Errorf("got principal %+v", principal)
+			}
+		})
+	}
+}
+
+func TestJWTVerifierPinsAlgorithms(t *testing.T) {
+	rsaKey := newTestRSAKey(t)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, jwksFile, rsaJWK("rsa-1", &rsaKey.PublicKey))
+
+	v, err := NewJWTVerifier(config.JWTConfig{JWKSFile: jwksFile, Issuer: testIssuer, Audience: testAudience})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	none, err := jwt.NewWithClaims(jwt.SigningMethodNone, testClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hs384 := jwt.NewWithClaims(jwt.SigningMethodHS384, testClaims())
+	hs384.Header["kid"] = "rsa-1"
+	hs384Token, err := hs384.SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// o clássico alg confusion: HS256 usando a chave pública RSA como segredo
+	pub := rsaJWK("rsa-1", &rsaKey.PublicKey)
+	confused := signHS256(t, testClaims(), "rsa-1", []byte(pub.N))
+
+	tests := map[string]string{
+		"none":           none,
+		"hs384":          hs384Token,
+		"alg confusion":  confused,
+		"rs256 no match": signRS256(t, testClaims(), "rsa-1", newTestRSAKey(t)),
+	}
+
+	for name, token := range tests {
+		if _, err := v.Verify(token); err == nil {
+			t.Errorf("%v: token accepted", name)
+		}
+	}
+}
+
+func TestJWTVerifierReloadsJWKSOnUnknownKid(t *testing.T) {
+	oldKey := newTestRSAKey(t)
+	newKey := newTestRSAKey(t)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, jwksFile, rsaJWK("old", &oldKey.PublicKey))
+
+	v, err := NewJWTVerifier(config.JWTConfig{JWKSFile: jwksFile, Issuer: testIssuer, Audience: testAudience})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token := signRS256(t, testClaims(), "new", newKey)
+	if _, err := v.Verify(token); !errors.Is(err, ErrTokenKeyNotFound) {
+		t.Fatalf("before rotation: got %v, want ErrTokenKeyNotFound", err)
+	}
+
+	writeJWKS(t, jwksFile, rsaJWK("old", &oldKey.PublicKey), rsaJWK("new", &newKey.PublicKey))
+	// garante um mtime diferente mesmo em sistemas de arquivos com resolução baixa
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(jwksFile, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := v.Verify(token); err != nil {
+		t.Fatalf("after rotation: %v", err)
+	}
+
+	// arquivo inválido mantém as chaves carregadas
+	if err := os.WriteFile(jwksFile, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	later := future.Add(time.Minute)
+	if err := os.Chtimes(jwksFile, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := v.Verify(signRS256(t, testClaims(), "other", newKey)); !errors.Is(err, ErrTokenKeyNotFound) {
+		t.Fatalf("broken file: got %v, want ErrTokenKeyNotFound", err)
+	}
+
+	if _, err := v.Verify(signRS256(t, testClaims(), "old", oldKey)); err != nil {
+		t.Errorf("previous keys dropped after failed reload: %v", err)
+	}
+}
+
+func TestNewJWTVerifierRequiresIssuerAndAudience(t *testing.T) {
+	cfgs := map[string]config.JWTConfig{
+		"no issuer":   {HMACSecret: testSecret, Audience: testAudience},
+		"no audience": {HMACSecret: testSecret, Issuer: testIssuer},
+	}
+
+	for name, cfg := range cfgs {
+		if _, err := NewJWTVerifier(cfg); !errors.Is(err, ErrTokenIssuerRequired) {
+			t.Errorf("%v: got %v, want ErrTokenIssuerRequired", name, err)
+		}
+	}
+}
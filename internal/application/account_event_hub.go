@@ -0,0 +1,52 @@
+package application
+
+import "sync"
+
+// avisa as streams de uma conta que existem eventos novos. O aviso não carrega o evento:
+// a stream busca no banco tudo depois da última versão enviada, então avisos podem ser
+// agrupados sem perder eventos
+type AccountEventHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan struct{}]struct{}
+}
+
+func NewAccountEventHub() *AccountEventHub {
+	return &AccountEventHub{
+		subscribers: make(map[string]map[chan struct{}]struct{}),
+	}
+}
+
+func (h *AccountEventHub) Subscribe(accountNumber string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	if _, ok := h.subscribers[accountNumber]; !ok {
+		h.subscribers[accountNumber] = make(map[chan struct{}]struct{})
+	}
+	h.subscribers[accountNumber][ch] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.subscribers[accountNumber], ch)
+		if len(h.subscribers[accountNumber]) == 0 {
+			delete(h.subscribers, accountNumber)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+func (h *AccountEventHub) Notify(accountNumber string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[accountNumber] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package application
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/adapter/database"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/apikey"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+// chaves de API para chamadas serviço a serviço. Só o hash é gravado, a chave em claro
+// é devolvida uma única vez na criação e na rotação
+type APIKeyService struct {
+	db port.APIKeyDatabasePort
+
+	mu       sync.Mutex
+	lastUsed map[uuid.UUID]time.Time
+}
+
+func NewAPIKeyService(db port.APIKeyDatabasePort) *APIKeyService {
+	return &APIKeyService{
+		db:       db,
+		lastUsed: make(map[uuid.UUID]time.Time),
+	}
+}
+
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, name string, scopes []string) (apikey.APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return apikey.APIKey{}, "", apikey.ErrAPIKeyNameRequired
+	}
+
+	if err := apikey.ValidateScopes(scopes); err != nil {
+		return apikey.APIKey{}, "", err
+	}
+
+	keyID, err := apikey.NewKeyID()
+	if err != nil {
+		return apikey.APIKey{}, "", err
+	}
+
+	key, err := apikey.NewKey(keyID)
+	if err != nil {
+		return apikey.APIKey{}, "", err
+	}
+
+	now := time.Now()
+	keyOrm := database.APIKeyOrm{
+		APIKeyUUID: uuid.New(),
+		KeyID:      keyID,
+		Name:       name,
+		KeyHash:    apikey.Hash(key),
+		Scopes:     strings.Join(scopes, " "),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if _, err := s.db.CreateAPIKey(ctx, keyOrm); err != nil {
+		return apikey.APIKey{}, "", err
+	}
+
+	return toAPIKey(keyOrm), key, nil
+}
+
+// gera um novo secret para a chave. Com gracePeriod > 0 a chave anterior continua válida
+// por esse tempo, para os clientes trocarem sem indisponibilidade
+func (s *APIKeyService) RotateAPIKey(ctx context.Context, apiKeyUUID uuid.UUID, gracePeriod time.Duration) (apikey.APIKey, string, error) {
+	keyOrm, err := s.db.GetAPIKey(ctx, apiKeyUUID)
+	if err != nil {
+		return apikey.APIKey{}, "", err
+	}
+
+	if keyOrm.RevokedAt != nil {
+		return apikey.APIKey{}, "", apikey.ErrAPIKeyRevoked
+	}
+
+	key, err := apikey.NewKey(keyOrm.KeyID)
+	if err != nil {
+		return apikey.APIKey{}, "", err
+	}
+
+	now := time.Now()
+
+	var previousHash *string
+	var previousExpiresAt *time.Time
+	if gracePeriod > 0 {
+		expiresAt := now.Add(gracePeriod)
+		previousHash = &keyOrm.KeyHash
+		previousExpiresAt = &expiresAt
+	}
+
+	if err := s.db.RotateAPIKey(ctx, apiKeyUUID, apikey.Hash(key), previousHash, previousExpiresAt, now); err != nil {
+		return apikey.APIKey{}, "", err
+	}
+
+	keyOrm.RotatedAt = &now
+
+	return toAPIKey(keyOrm), key, nil
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, apiKeyUUID uuid.UUID) error {
+	return s.db.RevokeAPIKey(ctx, apiKeyUUID, time.Now())
+}
+
+func (s *APIKeyService) ListAPIKeys(ctx context.Context) ([]apikey.APIKey, error) {
+	keysOrm, err := s.db.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]apikey.APIKey, 0, len(keysOrm))
+	for _, k := range keysOrm {
+		keys = append(keys, toAPIKey(k))
+	}
+
+	return keys, nil
+}
+
+// chamado pelo interceptor a cada RPC autenticada por chave
+func (s *APIKeyService) Authenticate(ctx context.Context, key string) (auth.Principal, error) {
+	keyID, err := apikey.ParseKeyID(key)
+	if err != nil {
+		return auth.Principal{}, err
+	}
+
+	keyOrm, err := s.db.GetAPIKeyByKeyID(ctx, keyID)
+	if err != nil {
+		return auth.Principal{}, err
+	}
+
+	now := time.Now()
+	hash := apikey.Hash(key)
+
+	if !hashMatches(hash, keyOrm.KeyHash) && !previousHashMatches(hash, keyOrm, now) {
+		return auth.Principal{}, apikey.ErrAPIKeyInvalid
+	}
+
+	if keyOrm.RevokedAt != nil {
+		return auth.Principal{}, apikey.ErrAPIKeyRevoked
+	}
+
+	s.touch(ctx, keyOrm.APIKeyUUID, now)
+
+	return toAPIKey(keyOrm).Principal(), nil
+}
+
+// grava last_used_at no máximo uma vez por LastUsedResolution por chave
+func (s *APIKeyService) touch(ctx context.Context, apiKeyUUID uuid.UUID, now time.Time) {
+	s.mu.Lock()
+	last, ok := s.lastUsed[apiKeyUUID]
+	if ok && now.Sub(last) < apikey.LastUsedResolution {
+		s.mu.Unlock()
+		return
+	}
+	s.lastUsed[apiKeyUUID] = now
+	s.mu.Unlock()
+
+	if err := s.db.TouchAPIKey(ctx, apiKeyUUID, now); err != nil {
+		logger.WarnContext(ctx, "failed to record api key use", "api_key_uuid", apiKeyUUID, "err", err)
+	}
+}
+
+func hashMatches(hash, stored string) bool {
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(stored)) == 1
+}
+
+func previousHashMatches(hash string, keyOrm database.APIKeyOrm, now time.Time) bool {
+	if keyOrm.PreviousKeyHash == nil || keyOrm.PreviousKeyExpiresAt == nil || !now.Before(*keyOrm.PreviousKeyExpiresAt) {
+		return false
+	}
+
+	return hashMatches(hash, *keyOrm.PreviousKeyHash)
+}
+
+func toAPIKey(k database.APIKeyOrm) apikey.APIKey {
+	return apikey.APIKey{
+		APIKeyUUID: k.APIKeyUUID,
+		KeyID:      k.KeyID,
+		Name:       k.Name,
+		Scopes:     strings.Fields(k.Scopes),
+		CreatedAt:  k.CreatedAt,
+		RotatedAt:  k.RotatedAt,
+		RevokedAt:  k.RevokedAt,
+		LastUsedAt: k.LastUsedAt,
+	}
+}
@@ -0,0 +1,245 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/adapter/database"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/apikey"
+)
+
+// bank_api_keys em memória
+type apiKeyFakeDB struct {
+	keys    map[uuid.UUID]database.APIKeyOrm
+	touches int
+}
+
+func newAPIKeyFakeDB() *apiKeyFakeDB {
+	return &apiKeyFakeDB{keys: make(map[uuid.UUID]database.APIKeyOrm)}
+}
+
+func (db *apiKeyFakeDB) CreateAPIKey(ctx context.Context, key database.APIKeyOrm) (uuid.UUID, error) {
+	db.keys[key.APIKeyUUID] = key
+	return key.APIKeyUUID, nil
+}
+
+func (db *apiKeyFakeDB) GetAPIKey(ctx context.Context, apiKeyUUID uuid.UUID) (database.APIKeyOrm, error) {
+	key, ok := db.keys[apiKeyUUID]
+	if !ok {
+		return database.APIKeyOrm{}, apikey.ErrAPIKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (db *apiKeyFakeDB) GetAPIKeyByKeyID(ctx context.Context, keyID string) (database.APIKeyOrm, error) {
+	for _, key := range db.keys {
+		if key.KeyID == keyID {
+			return key, nil
+		}
+	}
+
+	return database.APIKeyOrm{}, apikey.ErrAPIKeyNotFound
+}
+
+func (db *apiKeyFakeDB) ListAPIKeys(ctx context.Context) ([]database.APIKeyOrm, error) {
+	keys := make([]database.APIKeyOrm, 0, len(db.keys))
+	for _, key := range db.keys {
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (db *apiKeyFakeDB) RotateAPIKey(ctx context.Context, apiKeyUUID uuid.UUID, keyHash string, previousKeyHash *string,
+	previousExpiresAt *time.Time, ts time.Time) error {
+	key := db.keys[apiKeyUUID]
+	key.KeyHash = keyHash
+	key.PreviousKeyHash = previousKeyHash
+	key.PreviousKeyExpiresAt = previousExpiresAt
+	key.RotatedAt = &ts
+	db.keys[apiKeyUUID] = key
+
+	return nil
+}
+
+func (db *apiKeyFakeDB) RevokeAPIKey(ctx context.Context, apiKeyUUID uuid.UUID, ts time.Time) error {
+	key := db.keys[apiKeyUUID]
+	key.RevokedAt = &ts
+	db.keys[apiKeyUUID] = key
+
+	return nil
+}
+
+func (db *apiKeyFakeDB) TouchAPIKey(ctx context.Context, apiKeyUUID uuid.UUID, ts time.Time) error {
+	db.touches++
+	return nil
+}
+
+func TestAPIKeyAuthenticate(t *testing.T) {
+	tests := []struct {
+		name string
+		// devolve a chave apresentada pelo client depois de preparar o estado
+		setup   func(t *testing.T, s *APIKeyService, db *apiKeyFakeDB) string
+		wantErr error
+	}{
+		{
+			name: "valid key",
+			setup: func(t *testing.T, s *APIKeyService, db *apiKeyFakeDB) string {
+				_, key := createTestAPIKey(t, s)
+				return key
+			},
+		},
+		{
+			name: "malformed key",
+			setup: func(t *testing.T, s *APIKeyService, db *apiKeyFakeDB) string {
+				return "not-a-key"
+			},
+			wantErr: apikey.ErrAPIKeyInvalid,
+		},
+		{
+			name: "unknown key id",
+			setup: func(t *testing.T, s *APIKeyService, db *apiKeyFakeDB) string {
+				key, err := apikey.NewKey("0123456789abcdef")
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return key
+			},
+			wantErr: apikey.ErrAPIKeyNotFound,
+		},
+		{
+			name: "wrong secret",
+			setup: func(t *testing.T, s *APIKeyService, db *apiKeyFakeDB) string {
+				k, _ := createTestAPIKey(t, s)
+				other, err := apikey.NewKey(k.KeyID)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return other
+			},
+			wantErr: apikey.ErrAPIKeyInvalid,
+		},
+		{
+			name: "revoked key",
+			setup: func(t *testing.T, s *APIKeyService, db *apiKeyFakeDB) string {
+				k, key := createTestAPIKey(t, s)
+				if err := s.RevokeAPIKey(context.Background(), k.APIKeyUUID); err != nil {
+					t.Fatal(err)
+				}
+
+				return key
+			},
+			wantErr: apikey.ErrAPIKeyRevoked,
+		},
+		{
+			name: "previous secret inside the grace window",
+			setup: func(t *testing.T, s *APIKeyService, db *apiKeyFakeDB) string {
+				k, key := createTestAPIKey(t, s)
+				if _, _, err := s.RotateAPIKey(context.Background(), k.APIKeyUUID, time.Hour); err != nil {
+					t.Fatal(err)
+				}
+
+				return key
+			},
+		},
+		{
+			name: "new secret after rotation",
+			setup: func(t *testing.T, s *APIKeyService, db *apiKeyFakeDB) string {
+				k, _ := createTestAPIKey(t, s)
+				_, key, err := s.RotateAPIKey(context.Background(), k.APIKeyUUID, time.Hour)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				return key
+			},
+		},
+		{
+			name: "previous secret rotated without grace",
+			setup: func(t *testing.T, s *APIKeyService, db *apiKeyFakeDB) string {
+				k, key := createTestAPIKey(t, s)
+				if _, _, err := s.RotateAPIKey(context.Background(), k.APIKeyUUID, 0); err != nil {
+					t.Fatal(err)
+				}
+
+				return key
+			},
+			wantErr: apikey.ErrAPIKeyInvalid,
+		},
+		{
+			name: "previous secret after the grace window expired",
+			setup: func(t *testing.T, s *APIKeyService, db *apiKeyFakeDB) string {
+				k, key := createTestAPIKey(t, s)
+				if _, _, err := s.RotateAPIKey(context.Background(), k.APIKeyUUID, time.Hour); err != nil {
+					t.Fatal(err)
+				}
+
+				keyOrm := db.keys[k.APIKeyUUID]
+				expired := time.Now().Add(-time.Second)
+				keyOrm.PreviousKeyExpiresAt = &expired
+				db.keys[k.APIKeyUUID] = keyOrm
+
+				return key
+			},
+			wantErr: apikey.ErrAPIKeyInvalid,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newAPIKeyFakeDB()
+			s := NewAPIKeyService(db)
+			key := tc.setup(t, s, db)
+
+			principal, err := s.Authenticate(context.Background(), key)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("got %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Authenticate: %v", err)
+			}
+
+			if principal.Subject != "apikey:batch-job" || !strings.HasPrefix(key, apikey.KeyPrefix+principal.CredentialID+"_") {
+				t.Errorf("got principal %+v for key %q", principal, key)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuthenticateThrottlesLastUsed(t *testing.T) {
+	db := newAPIKeyFakeDB()
+	s := NewAPIKeyService(db)
+	_, key := createTestAPIKey(t, s)
+
+	for i := 0; i < 3; i++ {
+		if _, err := s.Authenticate(context.Background(), key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if db.touches != 1 {
+		t.Errorf("got %d last used updates, want 1", db.touches)
+	}
+}
+
+func createTestAPIKey(t *testing.T, s *APIKeyService) (apikey.APIKey, string) {
+	t.Helper()
+
+	k, key, err := s.CreateAPIKey(context.Background(), "batch-job", []string{"teller"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return k, key
+}
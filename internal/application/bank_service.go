@@ -1,35 +1,105 @@
 package application
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"log"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/viquitorreis/my-grpc-go-server/internal/adapter/database"
 	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/logging"
 	"github.com/viquitorreis/my-grpc-go-server/internal/port"
 )
 
+var logger = logging.Logger("application")
+
 type BankService struct {
-	db port.BankDatabasePort
+	db           port.BankDatabasePort
+	baseCurrency string
+	rateHub      port.ExchangeRateHubPort
+	eventHub     port.AccountEventHubPort
 }
 
 func NewBankService(port port.BankDatabasePort) *BankService {
-	return &BankService{db: port}
+	return &BankService{
+		db:           port,
+		baseCurrency: bank.DefaultBaseCurrency,
+	}
+}
+
+// hub notificado a cada taxa gravada, opcional
+func (s *BankService) SetExchangeRateHub(hub port.ExchangeRateHubPort) {
+	s.rateHub = hub
+}
+
+// hub notificado a cada lançamento nas contas, opcional
+func (s *BankService) SetAccountEventHub(hub port.AccountEventHubPort) {
+	s.eventHub = hub
+}
+
+func (s *BankService) notifyAccountEvents(accountNumbers ...string) {
+	if s.eventHub == nil {
+		return
+	}
+
+	for _, accountNumber := range accountNumbers {
+		s.eventHub.Notify(accountNumber)
+	}
+}
+
+func (s *BankService) FindAccountEvents(ctx context.Context, accountNumber string, afterVersion int64, limit int) ([]bank.AccountEvent, error) {
+	bankAccOrm, err := s.db.GetBankAccountNumber(ctx, accountNumber)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to get bank account", "account_number", accountNumber, "err", err)
+		return nil, fmt.Errorf("failed to get bank account number: %w", err)
+	}
+
+	eventsOrm, err := s.db.GetAccountEvents(ctx, bankAccOrm.AccountUUID, afterVersion, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]bank.AccountEvent, 0, len(eventsOrm))
+	for _, e := range eventsOrm {
+		res = append(res, bank.AccountEvent{
+			Version:         e.AccountVersion,
+			AccountNumber:   accountNumber,
+			EventType:       e.EventType,
+			TransactionUUID: e.TransactionUUID,
+			TransactionType: e.TransactionType,
+			Amount:          e.Amount,
+			Balance:         e.Balance,
+			Notes:           e.Notes,
+			Timestamp:       e.EventTimestamp,
+		})
+	}
+
+	return res, nil
 }
 
-func (s *BankService) FindCurrentBalance(accountId string) (float64, error) {
-	bankAccount, err := s.db.GetBankAccountNumber(accountId)
+func (s *BankService) FindCurrentBalance(ctx context.Context, accountId string) (float64, error) {
+	bankAccount, err := s.db.GetBankAccountNumber(ctx, accountId)
 	if err != nil {
-		log.Printf("failed to get bank account number: %v\n", err)
+		logger.WarnContext(ctx, "failed to get bank account", "account_number", accountId, "err", err)
 		return 0.0, err
 	}
 
 	return bankAccount.CurrentBalance, nil
 }
 
-func (s *BankService) CreateExchangeRate(r bank.ExchangeRate) (uuid.UUID, error) {
+func (s *BankService) CreateExchangeRate(ctx context.Context, r bank.ExchangeRate) (uuid.UUID, error) {
+	if err := s.validateExchangeRate(ctx, r); err != nil {
+		return uuid.Nil, err
+	}
+
 	newUUID := uuid.New()
 	now := time.Now()
 
@@ -44,36 +114,346 @@ func (s *BankService) CreateExchangeRate(r bank.ExchangeRate) (uuid.UUID, error)
 		UpdatedAt:          now,
 	}
 
-	return s.db.CreateExchangeRate(exchangeRateOrm)
+	savedUUID, err := s.db.CreateExchangeRate(ctx, exchangeRateOrm)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	if s.rateHub != nil {
+		s.rateHub.PublishExchangeRate(r)
+	}
+
+	return savedUUID, nil
 }
 
-func (s *BankService) GetExchangeRate(fromCurrency, toCurrency string, ts time.Time) (float64, error) {
-	exchangeRate, err := s.db.GetExchangeRate(fromCurrency, toCurrency, ts)
+func (s *BankService) validateExchangeRate(ctx context.Context, r bank.ExchangeRate) error {
+	if r.FromCurrency == "" || r.ToCurrency == "" || r.FromCurrency == r.ToCurrency {
+		return fmt.Errorf("%w: invalid currency pair %v/%v", bank.ErrInvalidExchangeRate, r.FromCurrency, r.ToCurrency)
+	}
+
+	if math.IsNaN(r.Rate) || math.IsInf(r.Rate, 0) || r.Rate <= 0 {
+		return fmt.Errorf("%w: rate must be positive, got %v", bank.ErrInvalidExchangeRate, r.Rate)
+	}
+
+	if !r.ValidToTimestamp.After(r.ValidFromTimestamp) {
+		return fmt.Errorf("%w: valid_to %v is not after valid_from %v", bank.ErrInvalidExchangeRate,
+			r.ValidToTimestamp, r.ValidFromTimestamp)
+	}
+
+	// compara com a última taxa conhecida do par para barrar valores absurdos do provider
+	previous, err := s.db.GetExchangeRate(ctx, r.FromCurrency, r.ToCurrency, r.ValidFromTimestamp)
+	if err != nil {
+		if errors.Is(err, bank.ErrExchangeRateNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if previous.Rate > 0 && math.Abs(r.Rate-previous.Rate)/previous.Rate > bank.MaxExchangeRateDelta {
+		return fmt.Errorf("%w: %v/%v from %v to %v", bank.ErrExchangeRateDeltaTooLarge,
+			r.FromCurrency, r.ToCurrency, previous.Rate, r.Rate)
+	}
+
+	return nil
+}
+
+func (s *BankService) GetExchangeRate(ctx context.Context, fromCurrency, toCurrency string, ts time.Time) (float64, error) {
+	rate, _, err := s.resolveExchangeRate(ctx, fromCurrency, toCurrency, ts)
 	if err != nil {
 		return 0, err
 	}
 
-	return float64(exchangeRate.Rate), nil
+	return rate, nil
+}
+
+func (s *BankService) GetExchangeRatePrice(ctx context.Context, fromCurrency, toCurrency, customerTier string, ts time.Time) (bank.ExchangeRatePrice, error) {
+	midRate, source, err := s.resolveExchangeRate(ctx, fromCurrency, toCurrency, ts)
+	if err != nil {
+		return bank.ExchangeRatePrice{}, err
+	}
+
+	if customerTier == "" {
+		customerTier = bank.CustomerTierDefault
+	}
+
+	spread := bank.DefaultExchangeSpread
+
+	spreadOrm, err := s.db.GetExchangeSpread(ctx, fromCurrency, toCurrency, customerTier)
+	if err == nil {
+		spread = spreadOrm.Spread
+	} else if !errors.Is(err, bank.ErrExchangeSpreadNotFound) {
+		return bank.ExchangeRatePrice{}, err
+	}
+
+	return bank.NewExchangeRatePrice(fromCurrency, toCurrency, midRate, spread, source), nil
+}
+
+// resolve a taxa média do par com as taxas gravadas no banco válidas em ts
+func (s *BankService) resolveExchangeRate(ctx context.Context, fromCurrency, toCurrency string, ts time.Time) (float64, string, error) {
+	return bank.ResolveMidRate(fromCurrency, toCurrency, s.baseCurrency, func(from, to string) (float64, error) {
+		exchangeRate, err := s.db.GetExchangeRate(ctx, from, to, ts)
+		if err != nil {
+			return 0, err
+		}
+
+		return exchangeRate.Rate, nil
+	})
+}
+
+func (s *BankService) GetExchangeRateHistory(ctx context.Context, fromCurrency, toCurrency string, start, end time.Time,
+	pageSize int, pageToken string) ([]bank.ExchangeRate, string, error) {
+	if !end.After(start) {
+		return nil, "", bank.ErrInvalidTimeRange
+	}
+
+	pageSize = normalizePageSize(pageSize)
+	query := pageQuery(fromCurrency, toCurrency, start, end, pageSize)
+
+	afterTimestamp, afterUUID, err := decodePageToken(pageToken, query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// busca um registro a mais para saber se existe próxima página
+	exchangeRatesOrm, err := s.db.GetExchangeRateHistory(ctx, fromCurrency, toCurrency, start, end, afterTimestamp, afterUUID, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextPageToken := ""
+	if len(exchangeRatesOrm) > pageSize {
+		exchangeRatesOrm = exchangeRatesOrm[:pageSize]
+		last := exchangeRatesOrm[pageSize-1]
+		nextPageToken = encodePageToken(last.ValidFromTimestamp, last.ExchangeRateUUID, query)
+	}
+
+	res := make([]bank.ExchangeRate, 0, len(exchangeRatesOrm))
+	for _, r := range exchangeRatesOrm {
+		res = append(res, bank.ExchangeRate{
+			FromCurrency:       r.FromCurrency,
+			ToCurrency:         r.ToCurrency,
+			Rate:               r.Rate,
+			ValidFromTimestamp: r.ValidFromTimestamp,
+			ValidToTimestamp:   r.ValidToTimestamp,
+		})
+	}
+
+	return res, nextPageToken, nil
+}
+
+func (s *BankService) GetExchangeRateCandles(ctx context.Context, fromCurrency, toCurrency, interval string, start, end time.Time,
+	pageSize int, pageToken string) ([]bank.ExchangeRateCandle, string, error) {
+	truncUnit, ok := bank.CandleIntervalUnits[interval]
+	if !ok {
+		return nil, "", bank.ErrInvalidCandleInterval
+	}
+
+	if !end.After(start) {
+		return nil, "", bank.ErrInvalidTimeRange
+	}
+
+	pageSize = normalizePageSize(pageSize)
+	query := pageQuery(fromCurrency, toCurrency, interval, start, end, pageSize)
+
+	// o token guarda o último bucket retornado, a próxima página começa no bucket seguinte
+	if pageToken != "" {
+		lastBucket, _, err := decodePageToken(pageToken, query)
+		if err != nil {
+			return nil, "", err
+		}
+
+		start = lastBucket.Add(bank.CandleIntervalDurations[interval])
+	}
+
+	candlesOrm, err := s.db.GetExchangeRateCandles(ctx, fromCurrency, toCurrency, truncUnit, start, end, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextPageToken := ""
+	if len(candlesOrm) > pageSize {
+		candlesOrm = candlesOrm[:pageSize]
+		nextPageToken = encodePageToken(candlesOrm[pageSize-1].Bucket, uuid.Nil, query)
+	}
+
+	res := make([]bank.ExchangeRateCandle, 0, len(candlesOrm))
+	for _, c := range candlesOrm {
+		res = append(res, bank.ExchangeRateCandle{
+			OpenTimestamp: c.Bucket,
+			Open:          c.Open,
+			High:          c.High,
+			Low:           c.Low,
+			Close:         c.Close,
+			Samples:       c.Samples,
+		})
+	}
+
+	return res, nextPageToken, nil
+}
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+func normalizePageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return defaultPageSize
+	}
+
+	if pageSize > maxPageSize {
+		return maxPageSize
+	}
+
+	return pageSize
+}
+
+// hash dos parâmetros da consulta que gerou o token. Um token só vale para a mesma consulta,
+// reutilizá-lo com outro par, intervalo ou tamanho de página devolveria páginas erradas
+func pageQuery(params ...any) string {
+	h := sha256.New()
+	for _, p := range params {
+		if t, ok := p.(time.Time); ok {
+			p = t.UnixNano()
+		}
+
+		fmt.Fprintf(h, "%v\x00", p)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+// page token opaco para o client: "<unix nano>:<uuid>:<hash da consulta>" em base64
+func encodePageToken(ts time.Time, id uuid.UUID, query string) string {
+	raw := fmt.Sprintf("%d:%s:%s", ts.UnixNano(), id.String(), query)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodePageToken(token, query string) (time.Time, uuid.UUID, error) {
+	if token == "" {
+		return time.Time{}, uuid.Nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, uuid.Nil, bank.ErrInvalidPageToken
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 || parts[2] != query {
+		return time.Time{}, uuid.Nil, bank.ErrInvalidPageToken
+	}
+
+	tsPart, idPart := parts[0], parts[1]
+
+	nanos, err := strconv.ParseInt(tsPart, 10, 64)
+	if err != nil {
+		return time.Time{}, uuid.Nil, bank.ErrInvalidPageToken
+	}
+
+	id, err := uuid.Parse(idPart)
+	if err != nil {
+		return time.Time{}, uuid.Nil, bank.ErrInvalidPageToken
+	}
+
+	return time.Unix(0, nanos).UTC(), id, nil
+}
+
+func (s *BankService) CreateExchangeQuote(ctx context.Context, fromCurrency, toCurrency, customerTier string) (bank.ExchangeQuote, error) {
+	now := time.Now()
+
+	price, err := s.GetExchangeRatePrice(ctx, fromCurrency, toCurrency, customerTier, now)
+	if err != nil {
+		return bank.ExchangeQuote{}, err
+	}
+
+	// o cliente vende a moeda de origem, então a cotação trava a taxa de compra do banco
+	quote := bank.ExchangeQuote{
+		QuoteUUID:    uuid.New(),
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		MidRate:      price.MidRate,
+		Spread:       price.Spread,
+		Rate:         price.BuyRate,
+		ExpiresAt:    now.Add(bank.DefaultExchangeQuoteTTL),
+	}
+
+	quoteOrm := database.BankExchangeQuoteOrm{
+		QuoteUUID:    quote.QuoteUUID,
+		FromCurrency: quote.FromCurrency,
+		ToCurrency:   quote.ToCurrency,
+		MidRate:      quote.MidRate,
+		Spread:       quote.Spread,
+		Rate:         quote.Rate,
+		ExpiresAt:    quote.ExpiresAt,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if _, err := s.db.CreateExchangeQuote(ctx, quoteOrm); err != nil {
+		return bank.ExchangeQuote{}, err
+	}
+
+	return quote, nil
+}
+
+// valida a cotação referenciada pela transferência, sem consumir
+func (s *BankService) checkExchangeQuote(ctx context.Context, quoteID string, fromAccOrm, toAccOrm database.BankAccountOrm, ts time.Time) (database.BankExchangeQuoteOrm, error) {
+	quoteUUID, err := uuid.Parse(quoteID)
+	if err != nil {
+		return database.BankExchangeQuoteOrm{}, bank.ErrExchangeQuoteNotFound
+	}
+
+	quoteOrm, err := s.db.GetExchangeQuote(ctx, quoteUUID)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to get exchange quote", "quote_id", quoteID, "err", err)
+		return quoteOrm, bank.ErrExchangeQuoteNotFound
+	}
+
+	if quoteOrm.FromCurrency != fromAccOrm.Currency || quoteOrm.ToCurrency != toAccOrm.Currency {
+		return quoteOrm, bank.ErrExchangeQuoteMismatch
+	}
+
+	if quoteOrm.UsedAt != nil {
+		return quoteOrm, bank.ErrExchangeQuoteUsed
+	}
+
+	if !ts.Before(quoteOrm.ExpiresAt) {
+		return quoteOrm, bank.ErrExchangeQuoteExpired
+	}
+
+	return quoteOrm, nil
 }
 
-func (s *BankService) CreateTransaction(account string, t bank.Transaction) (uuid.UUID, error) {
+func (s *BankService) CreateTransaction(ctx context.Context, account string, t bank.Transaction) (uuid.UUID, error) {
 	newUUID := uuid.New()
 	now := time.Now()
 
-	bankAccOrm, err := s.db.GetBankAccountNumber(account)
+	if err := validateTransaction(t); err != nil {
+		return uuid.Nil, err
+	}
+
+	bankAccOrm, err := s.db.GetBankAccountNumber(ctx, account)
 	if err != nil {
-		log.Printf("failed to get bank account number: %v\n", err)
-		return uuid.Nil, fmt.Errorf("failed to get bank account number: %w", err)
+		logger.WarnContext(ctx, "failed to get bank account", "account_number", account, "err", err)
+		return uuid.Nil, fmt.Errorf("%w: %v", bank.ErrTransactionAccountNotFound, account)
 	}
 
 	if t.TransactionType == bank.TransactionTypeOut && bankAccOrm.CurrentBalance < t.Amount {
-		return bankAccOrm.AccountUUID, fmt.Errorf("insufficient funds %v < %v", bankAccOrm.CurrentBalance, t.Amount)
+		return bankAccOrm.AccountUUID, fmt.Errorf("%w: %v < %v", bank.ErrTransactionInsufficientFunds, bankAccOrm.CurrentBalance, t.Amount)
+	}
+
+	// mantém o horário informado pelo client, só transações sem timestamp usam o horário atual
+	ts := t.Timestamp
+	if ts.IsZero() {
+		ts = now
 	}
 
 	transactionOrm := database.BankTransactionOrm{
 		TransactionUUID:      newUUID,
 		AccountUUID:          bankAccOrm.AccountUUID,
-		TransactionTimestamp: now,
+		TransactionTimestamp: ts,
 		Amount:               t.Amount,
 		TransactionType:      t.TransactionType,
 		Notes:                t.Notes,
@@ -81,46 +461,239 @@ func (s *BankService) CreateTransaction(account string, t bank.Transaction) (uui
 		UpdatedAt:            now,
 	}
 
-	savedUUID, err := s.db.CreateTransaction(bankAccOrm, transactionOrm)
+	savedUUID, err := s.db.CreateTransaction(ctx, bankAccOrm, transactionOrm)
+	if err == nil {
+		s.notifyAccountEvents(account)
+	}
+
 	return savedUUID, err
 }
 
-func (s *BankService) CalculateTransactionSummary(tsum *bank.TransactionSummary, trans bank.Transaction) error {
-	switch trans.TransactionType {
-	case bank.TransactionTypeIn:
-		tsum.SumIn += trans.Amount
-	case bank.TransactionTypeOut:
-		tsum.SumOut += trans.Amount
-	default:
-		return fmt.Errorf("unknown transaction type %v", trans.TransactionType)
+// grava a transação e a adiciona ao resumo. Com summarizeOnly a transação é apenas validada
+func (s *BankService) SummarizeTransaction(ctx context.Context, report *bank.TransactionSummaryReport, account string, t bank.Transaction, summarizeOnly bool) error {
+	if t.Timestamp.IsZero() {
+		t.Timestamp = time.Now()
 	}
 
-	tsum.SumTotal = tsum.SumIn - tsum.SumOut
+	if summarizeOnly {
+		if err := validateTransaction(t); err != nil {
+			return err
+		}
+
+		if _, err := s.db.GetBankAccountNumber(ctx, account); err != nil {
+			return fmt.Errorf("%w: %v", bank.ErrTransactionAccountNotFound, account)
+		}
+	} else if _, err := s.CreateTransaction(ctx, account, t); err != nil {
+		return err
+	}
+
+	report.Add(account, t)
+
+	return nil
+}
+
+func validateTransaction(t bank.Transaction) error {
+	if t.TransactionType != bank.TransactionTypeIn && t.TransactionType != bank.TransactionTypeOut {
+		return bank.ErrTransactionInvalidType
+	}
+
+	if t.Amount <= 0 || math.IsNaN(t.Amount) || math.IsInf(t.Amount, 0) {
+		return bank.ErrTransactionInvalidAmount
+	}
 
 	return nil
 }
 
-func (s *BankService) Transfer(tt bank.TransferTransaction) (uuid.UUID, bool, error) {
+// toda transferência que não é efetivada fica gravada como falha, com o evento TransferFailed no outbox
+func (s *BankService) Transfer(ctx context.Context, tt bank.TransferTransaction) (uuid.UUID, bool, error) {
 	now := time.Now()
 
-	fromAccOrm, err := s.db.GetBankAccountNumber(tt.FromAccountNumber)
+	p, err := s.prepareTransfer(ctx, tt, now, true)
 	if err != nil {
-		log.Printf("failed to get bank account number: %v\n", err)
-		return uuid.Nil, false, bank.ErrTransferSourceAccountNotFound
+		s.markTransferFailed(ctx, p.transfer, err)
+		return p.transfer.TransferUUID, false, err
 	}
 
-	// checando se a conta de destino tem saldo suficiente
-	if fromAccOrm.CurrentBalance < tt.Amount {
-		return uuid.Nil, false, bank.ErrTransferTransactionPair
+	if _, err := s.db.CreateTransfer(ctx, p.transfer); err != nil {
+		logger.ErrorContext(ctx, "failed to create transfer",
+			"from_account_number", tt.FromAccountNumber, "to_account_number", tt.ToAccountNumber, "err", err)
+		s.markTransferFailed(ctx, p.transfer, bank.ErrTransferRecordFailed)
+		return p.transfer.TransferUUID, false, bank.ErrTransferRecordFailed
 	}
 
-	toAccOrm, err := s.db.GetBankAccountNumber(tt.ToAccountNumber)
-	if err != nil {
-		log.Printf("failed to get bank account number: %v\n", err)
-		return uuid.Nil, false, bank.ErrTransferSourceAccountNotFound
+	// a cotação é consumida na mesma transação do par, então uma falha aqui não a gasta
+	if _, err := s.db.CreateTransferTransactionPair(ctx, p.transfer, p.fromAccount, p.toAccount, p.fromTransaction, p.toTransaction,
+		p.quoteUUID); err != nil {
+		if !errors.Is(err, bank.ErrExchangeQuoteUsed) {
+			logger.WarnContext(ctx, "failed to create transfer transaction pair", "transfer_uuid", p.transfer.TransferUUID, "err", err)
+			err = bank.ErrTransferTransactionPair
+		}
+
+		s.markTransferFailed(ctx, p.transfer, err)
+		return p.transfer.TransferUUID, false, err
 	}
 
-	fromTransactionOrm := database.BankTransactionOrm{
+	s.notifyAccountEvents(tt.FromAccountNumber, tt.ToAccountNumber)
+	return p.transfer.TransferUUID, true, nil
+}
+
+// executa todas as transferências do lote em uma única transação do banco: ou todas são
+// gravadas ou nenhuma. Em caso de falha o erro é um *bank.TransferBatchError com o índice do item
+// e todos os itens ficam gravados como falha
+func (s *BankService) TransferBatch(ctx context.Context, tts []bank.TransferTransaction) ([]uuid.UUID, error) {
+	if len(tts) > bank.MaxTransferBatchSize {
+		return nil, bank.ErrTransferBatchTooLarge
+	}
+
+	now := time.Now()
+	prepared := make([]preparedTransfer, 0, len(tts))
+
+	var prepareErr error
+
+	// todos os itens são montados mesmo depois de uma falha, para que o lote inteiro seja registrado.
+	// O saldo não é conferido aqui: um item pode depender de um crédito feito por um item anterior do
+	// mesmo lote, e o adapter confere cada item com o saldo relido dentro da transação
+	for i, tt := range tts {
+		p, err := s.prepareTransfer(ctx, tt, now, false)
+		if err != nil && prepareErr == nil {
+			prepareErr = &bank.TransferBatchError{Index: i, Err: err}
+		}
+
+		prepared = append(prepared, p)
+	}
+
+	if prepareErr != nil {
+		s.markTransferBatchFailed(ctx, prepared, prepareErr)
+		return nil, prepareErr
+	}
+
+	// as cotações são consumidas na transação do lote, então um lote desfeito não as gasta
+	items := make([]database.TransferBatchItemOrm, 0, len(prepared))
+	for _, p := range prepared {
+		items = append(items, database.TransferBatchItemOrm{
+			Transfer:        p.transfer,
+			FromTransaction: p.fromTransaction,
+			ToTransaction:   p.toTransaction,
+			QuoteUUID:       p.quoteUUID,
+		})
+	}
+
+	if err := s.db.CreateTransferBatch(ctx, items); err != nil {
+		logger.ErrorContext(ctx, "failed to create transfer batch", "transfers", len(items), "err", err)
+		s.markTransferBatchFailed(ctx, prepared, err)
+		return nil, err
+	}
+
+	transferUUIDs := make([]uuid.UUID, 0, len(items))
+	for i, item := range items {
+		transferUUIDs = append(transferUUIDs, item.Transfer.TransferUUID)
+		s.notifyAccountEvents(tts[i].FromAccountNumber, tts[i].ToAccountNumber)
+	}
+
+	return transferUUIDs, nil
+}
+
+// grava a transferência como falha. O erro de gravação só é logado, o motivo da falha continua sendo
+// o que volta para o client
+func (s *BankService) markTransferFailed(ctx context.Context, transfer database.BankTransferOrm, reason error) {
+	if err := s.db.MarkTransferFailed(ctx, transfer, reason.Error()); err != nil {
+		logger.ErrorContext(ctx, "failed to mark transfer as failed", "transfer_uuid", transfer.TransferUUID, "err", err)
+	}
+}
+
+// nenhum item de um lote desfeito foi gravado: o item que falhou leva o próprio motivo e os demais
+// o motivo do rollback
+func (s *BankService) markTransferBatchFailed(ctx context.Context, prepared []preparedTransfer, err error) {
+	var batchErr *bank.TransferBatchError
+	errors.As(err, &batchErr)
+
+	for i, p := range prepared {
+		reason := fmt.Errorf("%w: %v", bank.ErrTransferBatchRolledBack, err)
+		if batchErr != nil && batchErr.Index == i {
+			reason = batchErr.Err
+		}
+
+		s.markTransferFailed(ctx, p.transfer, reason)
+	}
+}
+
+type preparedTransfer struct {
+	transfer        database.BankTransferOrm
+	fromAccount     database.BankAccountOrm
+	toAccount       database.BankAccountOrm
+	fromTransaction database.BankTransactionOrm
+	toTransaction   database.BankTransactionOrm
+	quoteUUID       uuid.UUID
+}
+
+// valida as contas e a cotação (se houver) e monta os registros da transferência. A cotação
+// não é consumida aqui: o adapter a consome na transação que grava a transferência.
+// Mesmo com erro o registro da transferência volta preenchido com o que foi resolvido, para ser gravado como falha.
+// checkBalance confere o saldo da conta de origem lido fora da transação
+func (s *BankService) prepareTransfer(ctx context.Context, tt bank.TransferTransaction, now time.Time, checkBalance bool) (preparedTransfer, error) {
+	p := preparedTransfer{
+		transfer: database.BankTransferOrm{
+			TransferUUID:      uuid.New(),
+			Currency:          tt.Currency,
+			Amount:            tt.Amount,
+			TransferTimestamp: now,
+			TransferSuccess:   false,
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		},
+	}
+
+	// as duas contas são buscadas antes de qualquer validação para que a falha notifique as duas pontas
+	fromAccOrm, fromErr := s.db.GetBankAccountNumber(ctx, tt.FromAccountNumber)
+	if fromErr == nil {
+		p.transfer.FromAccountUUID = fromAccOrm.AccountUUID
+	}
+
+	toAccOrm, toErr := s.db.GetBankAccountNumber(ctx, tt.ToAccountNumber)
+	if toErr == nil {
+		p.transfer.ToAccountUUID = toAccOrm.AccountUUID
+	}
+
+	if tt.Amount <= 0 || math.IsNaN(tt.Amount) || math.IsInf(tt.Amount, 0) {
+		// NaN e infinito não cabem na coluna numeric nem no payload json
+		if math.IsNaN(tt.Amount) || math.IsInf(tt.Amount, 0) {
+			p.transfer.Amount = 0
+		}
+
+		return p, bank.ErrTransferInvalidAmount
+	}
+
+	if fromErr != nil {
+		logger.WarnContext(ctx, "failed to get transfer source account", "account_number", tt.FromAccountNumber, "err", fromErr)
+		return p, bank.ErrTransferSourceAccountNotFound
+	}
+
+	if toErr != nil {
+		logger.WarnContext(ctx, "failed to get transfer destination account", "account_number", tt.ToAccountNumber, "err", toErr)
+		return p, bank.ErrTransferDestinationAccountNotFound
+	}
+
+	// checando se a conta de origem tem saldo suficiente
+	if checkBalance && fromAccOrm.CurrentBalance < tt.Amount {
+		return p, bank.ErrTransferTransactionPair
+	}
+
+	// com cotação, a conta de destino recebe o valor convertido pela taxa travada
+	toAmount := tt.Amount
+
+	if tt.QuoteID != "" {
+		quoteOrm, err := s.checkExchangeQuote(ctx, tt.QuoteID, fromAccOrm, toAccOrm, now)
+		if err != nil {
+			return p, err
+		}
+
+		p.quoteUUID = quoteOrm.QuoteUUID
+		toAmount = math.Round(tt.Amount*quoteOrm.Rate*100) / 100
+	}
+
+	p.fromAccount = fromAccOrm
+	p.toAccount = toAccOrm
+	p.fromTransaction = database.BankTransactionOrm{
 		TransactionUUID:      uuid.New(),
 		TransactionTimestamp: now,
 		TransactionType:      bank.TransactionTypeOut,
@@ -130,42 +703,16 @@ func (s *BankService) Transfer(tt bank.TransferTransaction) (uuid.UUID, bool, er
 		CreatedAt:            now,
 		UpdatedAt:            now,
 	}
-
-	toTransactionOrm := database.BankTransactionOrm{
+	p.toTransaction = database.BankTransactionOrm{
 		TransactionUUID:      uuid.New(),
 		TransactionTimestamp: now,
 		TransactionType:      bank.TransactionTypeIn,
 		AccountUUID:          toAccOrm.AccountUUID,
-		Amount:               tt.Amount,
+		Amount:               toAmount,
 		Notes:                "Transfer from " + tt.FromAccountNumber,
 		CreatedAt:            now,
 		UpdatedAt:            now,
 	}
 
-	// create transfer request
-	newTransferUUID := uuid.New()
-
-	transferOrm := database.BankTransferOrm{
-		TransferUUID:      newTransferUUID,
-		FromAccountUUID:   fromAccOrm.AccountUUID,
-		ToAccountUUID:     toAccOrm.AccountUUID,
-		Currency:          tt.Currency,
-		Amount:            tt.Amount,
-		TransferTimestamp: now,
-		TransferSuccess:   false,
-		CreatedAt:         now,
-		UpdatedAt:         now,
-	}
-
-	if _, err := s.db.CreateTransfer(transferOrm); err != nil {
-		log.Printf("failed to create transfer de %v para %v : %v\n", tt.FromAccountNumber, tt.ToAccountNumber, err)
-		return uuid.Nil, false, bank.ErrTransferRecordFailed
-	}
-
-	if transferPairsucess, _ := s.db.CreateTransferTransactionPair(fromAccOrm, toAccOrm, fromTransactionOrm, toTransactionOrm); transferPairsucess {
-		s.db.UpdateTransferStatus(transferOrm, true)
-		return newTransferUUID, true, nil
-	} else {
-		return newTransferUUID, false, bank.ErrTransferTransactionPair
-	}
+	return p, nil
 }
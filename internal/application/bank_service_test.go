@@ -0,0 +1,142 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/adapter/database"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	query := pageQuery("USD", "EUR", start, end, 100)
+
+	ts := start.Add(time.Hour)
+	id := uuid.New()
+
+	gotTs, gotID, err := decodePageToken(encodePageToken(ts, id, query), query)
+	if err != nil {
+		t.Fatalf("decodePageToken: %v", err)
+	}
+
+	if !gotTs.Equal(ts) || gotID != id {
+		t.Errorf("decoded (%v, %v), want (%v, %v)", gotTs, gotID, ts, id)
+	}
+}
+
+func TestPageTokenRejectsOtherQuery(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	token := encodePageToken(start, uuid.New(), pageQuery("USD", "EUR", start, end, 100))
+
+	others := map[string]string{
+		"pair":      pageQuery("USD", "GBP", start, end, 100),
+		"range":     pageQuery("USD", "EUR", start, end.Add(time.Hour), 100),
+		"page size": pageQuery("USD", "EUR", start, end, 50),
+	}
+
+	for name, query := range others {
+		if _, _, err := decodePageToken(token, query); !errors.Is(err, bank.ErrInvalidPageToken) {
+			t.Errorf("%v: got %v, want ErrInvalidPageToken", name, err)
+		}
+	}
+}
+
+func TestDecodeEmptyPageToken(t *testing.T) {
+	ts, id, err := decodePageToken("", pageQuery("USD", "EUR"))
+	if err != nil || !ts.IsZero() || id != uuid.Nil {
+		t.Errorf("got (%v, %v, %v), want zero values", ts, id, err)
+	}
+}
+
+// taxas e spreads em memória para a precificação
+type pricingFakeDB struct {
+	port.BankDatabasePort
+	rates   map[[2]string]float64
+	spreads map[string]float64
+}
+
+func (db *pricingFakeDB) GetExchangeRate(ctx context.Context, fromCurrency, toCurrency string, ts time.Time) (database.BankExchangeRateOrm, error) {
+	rate, ok := db.rates[[2]string{fromCurrency, toCurrency}]
+	if !ok {
+		return database.BankExchangeRateOrm{}, bank.ErrExchangeRateNotFound
+	}
+
+	return database.BankExchangeRateOrm{FromCurrency: fromCurrency, ToCurrency: toCurrency, Rate: rate}, nil
+}
+
+func (db *pricingFakeDB) GetExchangeSpread(ctx context.Context, fromCurrency, toCurrency, customerTier string) (database.BankExchangeSpreadOrm, error) {
+	spread, ok := db.spreads[customerTier]
+	if !ok {
+		return database.BankExchangeSpreadOrm{}, bank.ErrExchangeSpreadNotFound
+	}
+
+	return database.BankExchangeSpreadOrm{FromCurrency: fromCurrency, ToCurrency: toCurrency, CustomerTier: customerTier, Spread: spread}, nil
+}
+
+func TestGetExchangeRatePrice(t *testing.T) {
+	db := &pricingFakeDB{
+		rates: map[[2]string]float64{
+			{"USD", "BRL"}: 5.0,
+			{"USD", "EUR"}: 0.8,
+		},
+		// PREMIUM sem spread gravado cai no default
+		spreads: map[string]float64{
+			bank.CustomerTierDefault:  0.02,
+			bank.CustomerTierStandard: 0.01,
+		},
+	}
+	s := NewBankService(db)
+
+	tests := []struct {
+		name       string
+		from, to   string
+		tier       string
+		wantMid    float64
+		wantSpread float64
+		wantSource string
+		wantErr    error
+	}{
+		{name: "direct with default tier", from: "USD", to: "BRL", tier: "",
+			wantMid: 5.0, wantSpread: 0.02, wantSource: bank.ExchangeRateSourceDirect},
+		{name: "inverse with standard tier", from: "BRL", to: "USD", tier: bank.CustomerTierStandard,
+			wantMid: 0.2, wantSpread: 0.01, wantSource: bank.ExchangeRateSourceInverse},
+		{name: "cross with premium tier falling back", from: "EUR", to: "BRL", tier: bank.CustomerTierPremium,
+			wantMid: 6.25, wantSpread: bank.DefaultExchangeSpread, wantSource: bank.ExchangeRateSourceCross},
+		{name: "missing rate", from: "USD", to: "JPY", tier: bank.CustomerTierStandard,
+			wantErr: bank.ErrExchangeRateNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := s.GetExchangeRatePrice(context.Background(), tc.from, tc.to, tc.tier, time.Now())
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("got %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetExchangeRatePrice: %v", err)
+			}
+
+			if math.Abs(p.MidRate-tc.wantMid) > 1e-9 || p.Source != tc.wantSource {
+				t.Errorf("got mid %v from %v, want %v from %v", p.MidRate, p.Source, tc.wantMid, tc.wantSource)
+			}
+
+			wantBuy := tc.wantMid * (1 - tc.wantSpread/2)
+			wantSell := tc.wantMid * (1 + tc.wantSpread/2)
+			if math.Abs(p.BuyRate-wantBuy) > 1e-9 || math.Abs(p.SellRate-wantSell) > 1e-9 {
+				t.Errorf("got buy %v and sell %v, want %v and %v", p.BuyRate, p.SellRate, wantBuy, wantSell)
+			}
+		})
+	}
+}
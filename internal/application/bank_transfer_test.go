@@ -0,0 +1,259 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/adapter/database"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+type failedTransfer struct {
+	transfer database.BankTransferOrm
+	reason   string
+}
+
+// banco fake para os caminhos de falha da transferência; grava as chamadas ao MarkTransferFailed
+type transferFakeDB struct {
+	port.BankDatabasePort
+
+	accounts map[string]database.BankAccountOrm
+	quotes   map[uuid.UUID]database.BankExchangeQuoteOrm
+	pairErr  error
+	batchErr error
+	batch    []database.TransferBatchItemOrm
+	failed   []failedTransfer
+}
+
+func (db *transferFakeDB) GetBankAccountNumber(ctx context.Context, account string) (database.BankAccountOrm, error) {
+	acc, ok := db.accounts[account]
+	if !ok {
+		return database.BankAccountOrm{}, errors.New("record not found")
+	}
+
+	return acc, nil
+}
+
+func (db *transferFakeDB) GetExchangeQuote(ctx context.Context, quoteUUID uuid.UUID) (database.BankExchangeQuoteOrm, error) {
+	q, ok := db.quotes[quoteUUID]
+	if !ok {
+		return database.BankExchangeQuoteOrm{}, errors.New("record not found")
+	}
+
+	return q, nil
+}
+
+func (db *transferFakeDB) CreateTransfer(ctx context.Context, transfer database.BankTransferOrm) (uuid.UUID, error) {
+	return transfer.TransferUUID, nil
+}
+
+func (db *transferFakeDB) CreateTransferTransactionPair(ctx context.Context, transferOrm database.BankTransferOrm, fromAccountOrm database.BankAccountOrm,
+	toAccountOrm database.BankAccountOrm, fromTransactionOrm database.BankTransactionOrm, toTransactionOrm database.BankTransactionOrm,
+	quoteUUID uuid.UUID) (bool, error) {
+	return db.pairErr == nil, db.pairErr
+}
+
+func (db *transferFakeDB) CreateTransferBatch(ctx context.Context, items []database.TransferBatchItemOrm) error {
+	db.batch = items
+	return db.batchErr
+}
+
+func (db *transferFakeDB) MarkTransferFailed(ctx context.Context, transfer database.BankTransferOrm, reason string) error {
+	db.failed = append(db.failed, failedTransfer{transfer: transfer, reason: reason})
+	return nil
+}
+
+func newTransferFakeDB() *transferFakeDB {
+	return &transferFakeDB{
+		accounts: map[string]database.BankAccountOrm{
+			"from-usd": {AccountUUID: uuid.New(), AccountNumber: "from-usd", Currency: "USD", CurrentBalance: 100},
+			"to-usd":   {AccountUUID: uuid.New(), AccountNumber: "to-usd", Currency: "USD", CurrentBalance: 0},
+			"to-eur":   {AccountUUID: uuid.New(), AccountNumber: "to-eur", Currency: "EUR", CurrentBalance: 0},
+		},
+		quotes: map[uuid.UUID]database.BankExchangeQuoteOrm{},
+	}
+}
+
+func TestTransferRecordsBusinessFailures(t *testing.T) {
+	db := newTransferFakeDB()
+	expiredQuote := uuid.New()
+	db.quotes[expiredQuote] = database.BankExchangeQuoteOrm{
+		QuoteUUID: expiredQuote, FromCurrency: "USD", ToCurrency: "EUR", Rate: 0.9,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	}
+
+	from := db.accounts["from-usd"].AccountUUID
+
+	tests := []struct {
+		name       string
+		tt         bank.TransferTransaction
+		wantErr    error
+		wantFrom   uuid.UUID
+		wantTo     uuid.UUID
+		wantAmount float64
+	}{
+		{
+			name:       "insufficient balance",
+			tt:         bank.TransferTransaction{FromAccountNumber: "from-usd", ToAccountNumber: "to-usd", Currency: "USD", Amount: 500},
+			wantErr:    bank.ErrTransferTransactionPair,
+			wantFrom:   from,
+			wantTo:     db.accounts["to-usd"].AccountUUID,
+			wantAmount: 500,
+		},
+		{
+			name:       "unknown destination",
+			tt:         bank.TransferTransaction{FromAccountNumber: "from-usd", ToAccountNumber: "missing", Currency: "USD", Amount: 10},
+			wantErr:    bank.ErrTransferDestinationAccountNotFound,
+			wantFrom:   from,
+			wantTo:     uuid.Nil,
+			wantAmount: 10,
+		},
+		{
+			name:       "negative amount",
+			tt:         bank.TransferTransaction{FromAccountNumber: "from-usd", ToAccountNumber: "to-usd", Currency: "USD", Amount: -1},
+			wantErr:    bank.ErrTransferInvalidAmount,
+			wantFrom:   from,
+			wantTo:     db.accounts["to-usd"].AccountUUID,
+			wantAmount: -1,
+		},
+		{
+			name:       "nan amount",
+			tt:         bank.TransferTransaction{FromAccountNumber: "from-usd", ToAccountNumber: "to-usd", Currency: "USD", Amount: math.NaN()},
+			wantErr:    bank.ErrTransferInvalidAmount,
+			wantFrom:   from,
+			wantTo:     db.accounts["to-usd"].AccountUUID,
+			wantAmount: 0,
+		},
+		{
+			name: "expired quote",
+			tt: bank.TransferTransaction{FromAccountNumber: "from-usd", ToAccountNumber: "to-eur", Currency: "USD", Amount: 10,
+				QuoteID: expiredQuote.String()},
+			wantErr:    bank.ErrExchangeQuoteExpired,
+			wantFrom:   from,
+			wantTo:     db.accounts["to-eur"].AccountUUID,
+			wantAmount: 10,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db.failed = nil
+			s := NewBankService(db)
+
+			transferUUID, ok, err := s.Transfer(context.Background(), tc.tt)
+			if ok || !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got (%v, %v), want (false, %v)", ok, err, tc.wantErr)
+			}
+
+			if len(db.failed) != 1 {
+				t.Fatalf("got %d failed transfers recorded, want 1", len(db.failed))
+			}
+
+			got := db.failed[0]
+			if got.transfer.TransferUUID != transferUUID || transferUUID == uuid.Nil {
+				t.Errorf("recorded transfer %v, returned %v", got.transfer.TransferUUID, transferUUID)
+			}
+
+			if got.transfer.FromAccountUUID != tc.wantFrom || got.transfer.ToAccountUUID != tc.wantTo {
+				t.Errorf("recorded accounts (%v, %v), want (%v, %v)",
+					got.transfer.FromAccountUUID, got.transfer.ToAccountUUID, tc.wantFrom, tc.wantTo)
+			}
+
+			if got.transfer.Amount != tc.wantAmount {
+				t.Errorf("recorded amount %v, want %v", got.transfer.Amount, tc.wantAmount)
+			}
+
+			if got.reason != tc.wantErr.Error() {
+				t.Errorf("recorded reason %q, want %q", got.reason, tc.wantErr.Error())
+			}
+		})
+	}
+}
+
+func TestTransferRecordsPairFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairErr error
+		wantErr error
+	}{
+		{name: "quote used concurrently", pairErr: bank.ErrExchangeQuoteUsed, wantErr: bank.ErrExchangeQuoteUsed},
+		{name: "database error", pairErr: errors.New("connection reset"), wantErr: bank.ErrTransferTransactionPair},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newTransferFakeDB()
+			db.pairErr = tc.pairErr
+			s := NewBankService(db)
+
+			tt := bank.TransferTransaction{FromAccountNumber: "from-usd", ToAccountNumber: "to-usd", Currency: "USD", Amount: 10}
+			if _, ok, err := s.Transfer(context.Background(), tt); ok || !errors.Is(err, tc.wantErr) {
+				t.Fatalf("got (%v, %v), want (false, %v)", ok, err, tc.wantErr)
+			}
+
+			if len(db.failed) != 1 || db.failed[0].reason != tc.wantErr.Error() {
+				t.Errorf("got failed transfers %+v, want one with reason %q", db.failed, tc.wantErr.Error())
+			}
+		})
+	}
+}
+
+func TestTransferBatchRecordsRolledBackItems(t *testing.T) {
+	db := newTransferFakeDB()
+	db.batchErr = &bank.TransferBatchError{Index: 1, Err: bank.ErrTransferTransactionPair}
+	s := NewBankService(db)
+
+	tts := []bank.TransferTransaction{
+		{FromAccountNumber: "from-usd", ToAccountNumber: "to-usd", Currency: "USD", Amount: 60},
+		{FromAccountNumber: "from-usd", ToAccountNumber: "to-usd", Currency: "USD", Amount: 60},
+		{FromAccountNumber: "from-usd", ToAccountNumber: "to-usd", Currency: "USD", Amount: 10},
+	}
+
+	if _, err := s.TransferBatch(context.Background(), tts); !errors.Is(err, bank.ErrTransferTransactionPair) {
+		t.Fatalf("got %v, want ErrTransferTransactionPair", err)
+	}
+
+	if len(db.failed) != len(tts) {
+		t.Fatalf("got %d failed transfers recorded, want %d", len(db.failed), len(tts))
+	}
+
+	for i, got := range db.failed {
+		if i == 1 {
+			if got.reason != bank.ErrTransferTransactionPair.Error() {
+				t.Errorf("item %d: reason %q, want %q", i, got.reason, bank.ErrTransferTransactionPair.Error())
+			}
+			continue
+		}
+
+		if !strings.HasPrefix(got.reason, bank.ErrTransferBatchRolledBack.Error()) {
+			t.Errorf("item %d: reason %q, want rolled back", i, got.reason)
+		}
+	}
+}
+
+func TestTransferBatchLeavesBalanceToTheTransaction(t *testing.T) {
+	db := newTransferFakeDB()
+	s := NewBankService(db)
+
+	// to-usd começa sem saldo e só pode repassar o valor depois do crédito do primeiro item
+	tts := []bank.TransferTransaction{
+		{FromAccountNumber: "from-usd", ToAccountNumber: "to-usd", Currency: "USD", Amount: 50},
+		{FromAccountNumber: "to-usd", ToAccountNumber: "from-usd", Currency: "USD", Amount: 50},
+	}
+
+	transferUUIDs, err := s.TransferBatch(context.Background(), tts)
+	if err != nil {
+		t.Fatalf("TransferBatch: %v", err)
+	}
+
+	if len(transferUUIDs) != 2 || len(db.batch) != 2 || len(db.failed) != 0 {
+		t.Errorf("got %d transfers, %d batch items and %d failures, want 2, 2 and 0",
+			len(transferUUIDs), len(db.batch), len(db.failed))
+	}
+}
@@ -0,0 +1,197 @@
+package account
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// eventos do stream de cada conta, o saldo é sempre derivado deles
+const (
+	EventAccountOpened    string = "AccountOpened"
+	EventDeposited        string = "Deposited"
+	EventWithdrawn        string = "Withdrawn"
+	EventTransferSent     string = "TransferSent"
+	EventTransferReceived string = "TransferReceived"
+)
+
+type EventData struct {
+	AccountNumber          string    `json:"account_number,omitempty"`
+	AccountName            string    `json:"account_name,omitempty"`
+	Currency               string    `json:"currency,omitempty"`
+	OpeningBalance         float64   `json:"opening_balance,omitempty"`
+	TransactionUUID        uuid.UUID `json:"transaction_uuid"`
+	TransferUUID           uuid.UUID `json:"transfer_uuid"`
+	CounterpartAccountUUID uuid.UUID `json:"counterpart_account_uuid"`
+	Amount                 float64   `json:"amount,omitempty"`
+	Notes                  string    `json:"notes,omitempty"`
+}
+
+type Event struct {
+	EventUUID     uuid.UUID
+	AccountUUID   uuid.UUID
+	StreamVersion int64
+	EventType     string
+	Data          EventData
+	Timestamp     time.Time
+}
+
+// aggregate da conta reconstruído a partir do stream. Version é a versão do último evento
+// persistido, usada como versão esperada no append (concorrência otimista)
+type Account struct {
+	AccountUUID   uuid.UUID
+	AccountNumber string
+	AccountName   string
+	Currency      string
+	Balance       float64
+	Version       int64
+	OpenedAt      time.Time
+	UpdatedAt     time.Time
+	changes       []Event
+}
+
+var ErrAccountStreamNotFound = errors.New("account stream not found")
+var ErrStreamVersionConflict = errors.New("account stream was modified concurrently")
+var ErrInvalidAmount = errors.New("amount must be positive")
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+func NewAccountFromEvents(events []Event) (*Account, error) {
+	if len(events) == 0 || events[0].EventType != EventAccountOpened {
+		return nil, ErrAccountStreamNotFound
+	}
+
+	a := &Account{}
+	for _, e := range events {
+		a.Apply(e)
+	}
+
+	return a, nil
+}
+
+func Open(accountUUID uuid.UUID, number, name, currency string, openingBalance float64, ts time.Time) (*Account, error) {
+	if openingBalance < 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	a := &Account{}
+	a.record(accountUUID, EventAccountOpened, EventData{
+		AccountNumber:  number,
+		AccountName:    name,
+		Currency:       currency,
+		OpeningBalance: openingBalance,
+	}, ts)
+
+	return a, nil
+}
+
+// aplica um evento ao estado, sem validação: eventos já persistidos são fatos
+func (a *Account) Apply(e Event) {
+	switch e.EventType {
+	case EventAccountOpened:
+		a.AccountUUID = e.AccountUUID
+		a.AccountNumber = e.Data.AccountNumber
+		a.AccountName = e.Data.AccountName
+		a.Currency = e.Data.Currency
+		a.Balance = e.Data.OpeningBalance
+		a.OpenedAt = e.Timestamp
+	case EventDeposited, EventTransferReceived:
+		a.Balance += e.Data.Amount
+	case EventWithdrawn, EventTransferSent:
+		a.Balance -= e.Data.Amount
+	}
+
+	a.Version = e.StreamVersion
+	a.UpdatedAt = e.Timestamp
+}
+
+func (a *Account) Deposit(transactionUUID uuid.UUID, amount float64, notes string, ts time.Time) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	a.record(a.AccountUUID, EventDeposited, EventData{
+		TransactionUUID: transactionUUID,
+		Amount:          amount,
+		Notes:           notes,
+	}, ts)
+
+	return nil
+}
+
+func (a *Account) Withdraw(transactionUUID uuid.UUID, amount float64, notes string, ts time.Time) error {
+	if err := a.checkDebit(amount); err != nil {
+		return err
+	}
+
+	a.record(a.AccountUUID, EventWithdrawn, EventData{
+		TransactionUUID: transactionUUID,
+		Amount:          amount,
+		Notes:           notes,
+	}, ts)
+
+	return nil
+}
+
+func (a *Account) SendTransfer(transferUUID, transactionUUID, toAccountUUID uuid.UUID, amount float64, notes string, ts time.Time) error {
+	if err := a.checkDebit(amount); err != nil {
+		return err
+	}
+
+	a.record(a.AccountUUID, EventTransferSent, EventData{
+		TransactionUUID:        transactionUUID,
+		TransferUUID:           transferUUID,
+		CounterpartAccountUUID: toAccountUUID,
+		Amount:                 amount,
+		Notes:                  notes,
+	}, ts)
+
+	return nil
+}
+
+func (a *Account) ReceiveTransfer(transferUUID, transactionUUID, fromAccountUUID uuid.UUID, amount float64, notes string, ts time.Time) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	a.record(a.AccountUUID, EventTransferReceived, EventData{
+		TransactionUUID:        transactionUUID,
+		TransferUUID:           transferUUID,
+		CounterpartAccountUUID: fromAccountUUID,
+		Amount:                 amount,
+		Notes:                  notes,
+	}, ts)
+
+	return nil
+}
+
+// eventos gerados pelos comandos e ainda não persistidos
+func (a *Account) Changes() []Event {
+	return a.changes
+}
+
+func (a *Account) checkDebit(amount float64) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	if a.Balance < amount {
+		return ErrInsufficientFunds
+	}
+
+	return nil
+}
+
+func (a *Account) record(accountUUID uuid.UUID, eventType string, data EventData, ts time.Time) {
+	e := Event{
+		EventUUID:     uuid.New(),
+		AccountUUID:   accountUUID,
+		StreamVersion: a.Version + 1,
+		EventType:     eventType,
+		Data:          data,
+		Timestamp:     ts,
+	}
+
+	a.Apply(e)
+	a.changes = append(a.changes, e)
+}
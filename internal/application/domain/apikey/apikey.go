@@ -0,0 +1,129 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+)
+
+// formato da chave: bk_<key id>_<secret>. O key id é público e usado na busca,
+// só o hash SHA-256 da chave inteira é gravado
+const (
+	KeyPrefix = "bk_"
+
+	keyIDBytes  = 8
+	secretBytes = 32
+)
+
+// escopos são papéis (customer, teller, admin) ou account:<número> para as contas de que a chave é titular
+const AccountScopePrefix = "account:"
+
+// metadata aceito pelo interceptor, além de "authorization: ApiKey <chave>"
+const MetadataKey = "x-api-key"
+
+// quanto tempo o last_used_at pode ficar desatualizado, para não gravar a cada chamada
+const LastUsedResolution = time.Minute
+
+var (
+	ErrAPIKeyNotFound     = errors.New("api key not found")
+	ErrAPIKeyInvalid      = errors.New("invalid api key")
+	ErrAPIKeyRevoked      = errors.New("api key revoked")
+	ErrAPIKeyInvalidScope = errors.New("invalid api key scope")
+	ErrAPIKeyNameRequired = errors.New("api key name is required")
+)
+
+type APIKey struct {
+	APIKeyUUID uuid.UUID
+	KeyID      string
+	Name       string
+	Scopes     []string
+	CreatedAt  time.Time
+	RotatedAt  *time.Time
+	RevokedAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+func (k APIKey) Principal() auth.Principal {
+	p := auth.Principal{
+		Subject:      "apikey:" + k.Name,
+		Method:       auth.MethodAPIKey,
+		CredentialID: k.KeyID,
+	}
+
+	for _, s := range k.Scopes {
+		if account, ok := strings.CutPrefix(s, AccountScopePrefix); ok {
+			p.Accounts = append(p.Accounts, account)
+			continue
+		}
+
+		p.Roles = append(p.Roles, s)
+	}
+
+	// contas nos escopos só valem como titularidade com o papel customer
+	if len(p.Accounts) > 0 && !p.HasRole(auth.RoleCustomer) {
+		p.Roles = append(p.Roles, auth.RoleCustomer)
+	}
+
+	return p
+}
+
+func ValidateScopes(scopes []string) error {
+	for _, s := range scopes {
+		switch {
+		case s == auth.RoleCustomer, s == auth.RoleTeller, s == auth.RoleAdmin:
+		case strings.HasPrefix(s, AccountScopePrefix) && len(s) > len(AccountScopePrefix):
+		default:
+			return fmt.Errorf("%w %q: use customer, teller, admin or %v<number>", ErrAPIKeyInvalidScope, s, AccountScopePrefix)
+		}
+	}
+
+	return nil
+}
+
+// gera um key id novo, usado na criação
+func NewKeyID() (string, error) {
+	b := make([]byte, keyIDBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate api key id: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// gera uma chave para o key id. Na rotação o key id é mantido e só o secret muda
+func NewKey(keyID string) (string, error) {
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	return KeyPrefix + keyID + "_" + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func ParseKeyID(key string) (string, error) {
+	rest, ok := strings.CutPrefix(key, KeyPrefix)
+	if !ok {
+		return "", ErrAPIKeyInvalid
+	}
+
+	keyID, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(keyID) != 2*keyIDBytes || secret == "" {
+		return "", ErrAPIKeyInvalid
+	}
+
+	return keyID, nil
+}
+
+// a chave tem 256 bits aleatórios, então um SHA-256 simples basta para não guardá-la em claro
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// certificado de cliente verificado no handshake mTLS
+type ClientCertificate struct {
+	CommonName     string
+	DNSNames       []string
+	URIs           []string
+	EmailAddresses []string
+	SerialNumber   string
+}
+
+// identidade preferida para autorização: SAN URI (ex. SPIFFE), depois DNS, e-mail e por fim o CN
+func (c ClientCertificate) Identity() string {
+	switch {
+	case len(c.URIs) > 0:
+		return c.URIs[0]
+	case len(c.DNSNames) > 0:
+		return c.DNSNames[0]
+	case len(c.EmailAddresses) > 0:
+		return c.EmailAddresses[0]
+	default:
+		return c.CommonName
+	}
+}
+
+type clientCertificateKey struct{}
+
+func WithClientCertificate(ctx context.Context, cert ClientCertificate) context.Context {
+	return context.WithValue(ctx, clientCertificateKey{}, cert)
+}
+
+func ClientCertificateFromContext(ctx context.Context) (ClientCertificate, bool) {
+	cert, ok := ctx.Value(clientCertificateKey{}).(ClientCertificate)
+	return cert, ok
+}
+
+const (
+	MethodJWT    string = "jwt"
+	MethodAPIKey string = "api_key"
+)
+
+const (
+	RoleCustomer string = "customer"
+	RoleTeller   string = "teller"
+	RoleAdmin    string = "admin"
+)
+
+// ações autorizadas por conta. As que não recebem conta (importação e operação de webhooks) são só de admin
+type Action string
+
+const (
+	ActionViewAccount        Action = "view_account"
+	ActionPostTransaction    Action = "post_transaction"
+	ActionTransfer           Action = "transfer"
+	ActionManageWebhooks     Action = "manage_webhooks"
+	ActionImportTransactions Action = "import_transactions"
+	ActionOperateWebhooks    Action = "operate_webhooks"
+)
+
+// papéis que podem executar a ação em qualquer conta. O titular (customer dono da conta) e o admin
+// sempre podem executar as ações de conta
+var actionRoles = map[Action][]string{
+	ActionViewAccount:     {RoleTeller},
+	ActionPostTransaction: {RoleTeller},
+	ActionTransfer:        {RoleTeller},
+}
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// chamador autenticado. Accounts são os números das contas de que ele é titular, Tier é o tier
+// de preço do cliente (vazio usa o default) e Claims guarda as claims originais do token.
+// CredentialID identifica a credencial de forma única (key_id da chave de API, vazio no JWT)
+type Principal struct {
+	Subject      string
+	Method       string
+	CredentialID string
+	Roles        []string
+	Accounts     []string
+	Tier         string
+	Claims       map[string]any
+}
+
+func (p Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+func (p Principal) OwnsAccount(accountNumber string) bool {
+	return accountNumber != "" && p.HasRole(RoleCustomer) && slices.Contains(p.Accounts, accountNumber)
+}
+
+// accountNumber vazio indica uma ação sem conta específica
+func (p Principal) Authorize(action Action, accountNumber string) error {
+	if p.HasRole(RoleAdmin) {
+		return nil
+	}
+
+	if accountNumber != "" {
+		if p.OwnsAccount(accountNumber) {
+			switch action {
+			case ActionViewAccount, ActionPostTransaction, ActionTransfer, ActionManageWebhooks:
+				return nil
+			}
+		}
+
+		for _, role := range actionRoles[action] {
+			if p.HasRole(role) {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("%w: %v cannot %v on account %v", ErrPermissionDenied, p.Subject, action, accountNumber)
+	}
+
+	return fmt.Errorf("%w: %v cannot %v", ErrPermissionDenied, p.Subject, action)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPrincipalAuthorize(t *testing.T) {
+	customer := Principal{Subject: "c1", Roles: []string{RoleCustomer}, Accounts: []string{"ACC-1"}}
+	// conta na lista mas sem o papel customer não dá titularidade
+	accountsOnly := Principal{Subject: "x1", Accounts: []string{"ACC-1"}}
+	teller := Principal{Subject: "t1", Roles: []string{RoleTeller}}
+	admin := Principal{Subject: "a1", Roles: []string{RoleAdmin}}
+	anonymous := Principal{Subject: "n1"}
+
+	tests := []struct {
+		name      string
+		principal Principal
+		action    Action
+		account   string
+		allowed   bool
+	}{
+		{"customer views own account", customer, ActionViewAccount, "ACC-1", true},
+		{"customer posts on own account", customer, ActionPostTransaction, "ACC-1", true},
+		{"customer transfers from own account", customer, ActionTransfer, "ACC-1", true},
+		{"customer manages own webhooks", customer, ActionManageWebhooks, "ACC-1", true},
+		{"customer views other account", customer, ActionViewAccount, "ACC-2", false},
+		{"customer transfers from other account", customer, ActionTransfer, "ACC-2", false},
+		{"customer imports transactions", customer, ActionImportTransactions, "", false},
+		{"accounts without customer role", accountsOnly, ActionViewAccount, "ACC-1", false},
+
+		{"teller views any account", teller, ActionViewAccount, "ACC-2", true},
+		{"teller posts on any account", teller, ActionPostTransaction, "ACC-2", true},
+		{"teller transfers from any account", teller, ActionTransfer, "ACC-2", true},
+		{"teller manages webhooks", teller, ActionManageWebhooks, "ACC-2", false},
+		{"teller imports transactions", teller, ActionImportTransactions, "", false},
+		{"teller operates webhooks", teller, ActionOperateWebhooks, "", false},
+
+		{"admin views any account", admin, ActionViewAccount, "ACC-2", true},
+		{"admin manages webhooks", admin, ActionManageWebhooks, "ACC-2", true},
+		{"admin imports transactions", admin, ActionImportTransactions, "", true},
+		{"admin operates webhooks", admin, ActionOperateWebhooks, "", true},
+
+		{"no role views account", anonymous, ActionViewAccount, "ACC-1", false},
+		{"no role account-less action", anonymous, ActionImportTransactions, "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.principal.Authorize(tc.action, tc.account)
+
+			if tc.allowed && err != nil {
+				t.Errorf("got %v, want allowed", err)
+			}
+
+			if !tc.allowed && !errors.Is(err, ErrPermissionDenied) {
+				t.Errorf("got %v, want ErrPermissionDenied", err)
+			}
+		})
+	}
+}
@@ -2,7 +2,11 @@ package bank
 
 import (
 	"errors"
+	"fmt"
+	"sort"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 const (
@@ -11,6 +15,27 @@ const (
 	TransactionTypeOut     string = "OUT"
 )
 
+const (
+	CustomerTierDefault  string = "DEFAULT"
+	CustomerTierStandard string = "STANDARD"
+	CustomerTierPremium  string = "PREMIUM"
+)
+
+const (
+	ExchangeRateSourceDirect  string = "DIRECT"
+	ExchangeRateSourceInverse string = "INVERSE"
+	ExchangeRateSourceCross   string = "CROSS"
+)
+
+const (
+	DefaultBaseCurrency     string        = "USD"
+	DefaultExchangeSpread   float64       = 0.01
+	DefaultExchangeQuoteTTL time.Duration = 30 * time.Second
+)
+
+// variação máxima aceita entre uma taxa nova e a última taxa do par (20%)
+const MaxExchangeRateDelta float64 = 0.2
+
 type ExchangeRate struct {
 	FromCurrency       string
 	ToCurrency         string
@@ -19,6 +44,135 @@ type ExchangeRate struct {
 	ValidToTimestamp   time.Time
 }
 
+// taxa de câmbio já resolvida (direta, inversa ou cruzada) com o spread aplicado,
+// BuyRate é a taxa em que o banco compra a moeda de origem e SellRate em que vende
+type ExchangeRatePrice struct {
+	FromCurrency string
+	ToCurrency   string
+	MidRate      float64
+	BuyRate      float64
+	SellRate     float64
+	Spread       float64
+	Source       string
+}
+
+// aplica o spread dividido igualmente entre compra e venda em torno da taxa média
+func NewExchangeRatePrice(fromCurrency, toCurrency string, midRate, spread float64, source string) ExchangeRatePrice {
+	return ExchangeRatePrice{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		MidRate:      midRate,
+		BuyRate:      midRate * (1 - spread/2),
+		SellRate:     midRate * (1 + spread/2),
+		Spread:       spread,
+		Source:       source,
+	}
+}
+
+// resolve a taxa média do par: direta, inversa ou cruzada pela moeda base. lookup devolve a taxa
+// gravada de um par ou ErrExchangeRateNotFound, e é a única diferença entre o banco e o cache do hub
+func ResolveMidRate(fromCurrency, toCurrency, base string, lookup func(from, to string) (float64, error)) (float64, string, error) {
+	if fromCurrency == toCurrency {
+		return 1, ExchangeRateSourceDirect, nil
+	}
+
+	rate, source, err := directOrInverseRate(fromCurrency, toCurrency, lookup)
+	if err == nil {
+		return rate, source, nil
+	}
+
+	if !errors.Is(err, ErrExchangeRateNotFound) {
+		return 0, "", err
+	}
+
+	if fromCurrency == base || toCurrency == base {
+		return 0, "", ErrExchangeRateNotFound
+	}
+
+	fromBaseRate, _, err := directOrInverseRate(fromCurrency, base, lookup)
+	if err != nil {
+		return 0, "", err
+	}
+
+	baseToRate, _, err := directOrInverseRate(base, toCurrency, lookup)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return fromBaseRate * baseToRate, ExchangeRateSourceCross, nil
+}
+
+func directOrInverseRate(fromCurrency, toCurrency string, lookup func(from, to string) (float64, error)) (float64, string, error) {
+	rate, err := lookup(fromCurrency, toCurrency)
+	if err == nil {
+		return rate, ExchangeRateSourceDirect, nil
+	}
+
+	if !errors.Is(err, ErrExchangeRateNotFound) {
+		return 0, "", err
+	}
+
+	inverseRate, err := lookup(toCurrency, fromCurrency)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if inverseRate <= 0 {
+		return 0, "", ErrExchangeRateNotFound
+	}
+
+	return 1 / inverseRate, ExchangeRateSourceInverse, nil
+}
+
+const (
+	DeliveryModeOnChange  string = "ON_CHANGE"
+	DeliveryModeInterval  string = "INTERVAL"
+	DeliveryModeThrottled string = "THROTTLED"
+)
+
+const (
+	DefaultDeliveryInterval time.Duration = 3 * time.Second
+	MinDeliveryInterval     time.Duration = 100 * time.Millisecond
+)
+
+const (
+	CandleIntervalMinute string = "1m"
+	CandleIntervalHour   string = "1h"
+	CandleIntervalDay    string = "1d"
+)
+
+// unidade usada no date_trunc do postgres para cada intervalo de candle
+var CandleIntervalUnits = map[string]string{
+	CandleIntervalMinute: "minute",
+	CandleIntervalHour:   "hour",
+	CandleIntervalDay:    "day",
+}
+
+var CandleIntervalDurations = map[string]time.Duration{
+	CandleIntervalMinute: time.Minute,
+	CandleIntervalHour:   time.Hour,
+	CandleIntervalDay:    24 * time.Hour,
+}
+
+type ExchangeRateCandle struct {
+	OpenTimestamp time.Time
+	Open          float64
+	High          float64
+	Low           float64
+	Close         float64
+	Samples       int64
+}
+
+type ExchangeQuote struct {
+	QuoteUUID    uuid.UUID
+	FromCurrency string
+	ToCurrency   string
+	MidRate      float64
+	Spread       float64
+	Rate         float64
+	ExpiresAt    time.Time
+}
+
 type Transaction struct {
 	Amount          float64
 	Timestamp       time.Time
@@ -26,11 +180,197 @@ type Transaction struct {
 	Notes           string
 }
 
+// linha de um arquivo de importação em lote, Row é a linha no arquivo de origem
+type ImportedTransaction struct {
+	Row           int
+	AccountNumber string
+	Transaction
+}
+
+// erro de validação de uma linha, a importação continua com as demais
+type TransactionImportError struct {
+	Row     int
+	Field   string
+	Message string
+}
+
+func (e *TransactionImportError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("row %d: %v", e.Row, e.Message)
+	}
+
+	return fmt.Sprintf("row %d: %v: %v", e.Row, e.Field, e.Message)
+}
+
+type TransactionImportResult struct {
+	RowsRead        int
+	RowsImported    int
+	RowsFailed      int
+	DryRun          bool
+	Errors          []TransactionImportError
+	ErrorsTruncated bool
+}
+
+// erros além do limite só são contados
+func (r *TransactionImportResult) AddError(e TransactionImportError) {
+	r.RowsFailed++
+
+	if len(r.Errors) >= MaxReportedImportErrors {
+		r.ErrorsTruncated = true
+		return
+	}
+
+	r.Errors = append(r.Errors, e)
+}
+
+const (
+	DefaultImportBatchSize    = 1000
+	MaxReportedImportErrors   = 1000
+	MaxImportTransactionValue = 1e13
+)
+
+const AccountEventTransactionPosted string = "TRANSACTION_POSTED"
+
+// evento de conta com versão crescente e sem buracos dentro da conta, usada pelo client para retomar a stream
+type AccountEvent struct {
+	Version         int64
+	AccountNumber   string
+	EventType       string
+	TransactionUUID uuid.UUID
+	TransactionType string
+	Amount          float64
+	Balance         float64
+	Notes           string
+	Timestamp       time.Time
+}
+
+const (
+	AggregateTypeAccount  string = "Account"
+	AggregateTypeTransfer string = "Transfer"
+)
+
+const (
+	EventTypeTransactionCreated string = "TransactionCreated"
+	EventTypeTransferCompleted  string = "TransferCompleted"
+	EventTypeTransferFailed     string = "TransferFailed"
+)
+
+// evento de domínio gravado no outbox na mesma transação da operação que o gerou
+// AggregateVersion cresce sem buracos dentro do agregado e é a ordem garantida para o consumidor;
+// a Sequence global pode ficar fora de ordem entre agregados diferentes
+type OutboxEvent struct {
+	Sequence         int64
+	EventUUID        uuid.UUID
+	AggregateType    string
+	AggregateUUID    uuid.UUID
+	AggregateVersion int64
+	EventType        string
+	Payload          []byte
+	Attempts         int
+	CreatedAt        time.Time
+}
+
+type TransactionCreatedPayload struct {
+	TransactionUUID uuid.UUID `json:"transaction_uuid"`
+	AccountUUID     uuid.UUID `json:"account_uuid"`
+	TransactionType string    `json:"transaction_type"`
+	Amount          float64   `json:"amount"`
+	Balance         float64   `json:"balance"`
+	Notes           string    `json:"notes"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+type TransferCompletedPayload struct {
+	TransferUUID        uuid.UUID `json:"transfer_uuid"`
+	FromAccountUUID     uuid.UUID `json:"from_account_uuid"`
+	ToAccountUUID       uuid.UUID `json:"to_account_uuid"`
+	FromTransactionUUID uuid.UUID `json:"from_transaction_uuid"`
+	ToTransactionUUID   uuid.UUID `json:"to_transaction_uuid"`
+	Currency            string    `json:"currency"`
+	AmountOut           float64   `json:"amount_out"`
+	AmountIn            float64   `json:"amount_in"`
+	Timestamp           time.Time `json:"timestamp"`
+}
+
+type TransferFailedPayload struct {
+	TransferUUID    uuid.UUID `json:"transfer_uuid"`
+	FromAccountUUID uuid.UUID `json:"from_account_uuid"`
+	ToAccountUUID   uuid.UUID `json:"to_account_uuid"`
+	Currency        string    `json:"currency"`
+	Amount          float64   `json:"amount"`
+	Reason          string    `json:"reason"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
 type TransactionSummary struct {
-	SummaryOnDate time.Time
-	SumIn         float64
-	SumOut        float64
-	SumTotal      float64
+	SummaryOnDate    time.Time
+	SumIn            float64
+	SumOut           float64
+	SumTotal         float64
+	TransactionCount int
+}
+
+func (s *TransactionSummary) add(t Transaction) {
+	switch t.TransactionType {
+	case TransactionTypeIn:
+		s.SumIn += t.Amount
+	case TransactionTypeOut:
+		s.SumOut += t.Amount
+	}
+
+	s.SumTotal = s.SumIn - s.SumOut
+	s.TransactionCount++
+
+	if t.Timestamp.After(s.SummaryOnDate) {
+		s.SummaryOnDate = t.Timestamp
+	}
+}
+
+// resumo por conta com os dias em ordem cronológica. SummaryOnDate de cada dia é o início do dia
+type AccountTransactionSummary struct {
+	AccountNumber string
+	Summary       TransactionSummary
+	Days          []TransactionSummary
+}
+
+// resumo geral de SummarizeTransactions, com as contas na ordem em que apareceram na stream
+type TransactionSummaryReport struct {
+	Summary  TransactionSummary
+	Accounts []AccountTransactionSummary
+	accounts map[string]int
+}
+
+// o dia da transação é o calendário do fuso informado no timestamp
+func (r *TransactionSummaryReport) Add(accountNumber string, t Transaction) {
+	if r.accounts == nil {
+		r.accounts = make(map[string]int)
+	}
+
+	i, ok := r.accounts[accountNumber]
+	if !ok {
+		i = len(r.Accounts)
+		r.accounts[accountNumber] = i
+		r.Accounts = append(r.Accounts, AccountTransactionSummary{AccountNumber: accountNumber})
+	}
+
+	acc := &r.Accounts[i]
+	// a data do calendário vira chave em UTC para agrupar o mesmo dia vindo de fusos diferentes
+	day := time.Date(t.Timestamp.Year(), t.Timestamp.Month(), t.Timestamp.Day(), 0, 0, 0, 0, time.UTC)
+
+	d := sort.Search(len(acc.Days), func(j int) bool {
+		return !acc.Days[j].SummaryOnDate.Before(day)
+	})
+
+	if d == len(acc.Days) || !acc.Days[d].SummaryOnDate.Equal(day) {
+		acc.Days = append(acc.Days, TransactionSummary{})
+		copy(acc.Days[d+1:], acc.Days[d:])
+		acc.Days[d] = TransactionSummary{SummaryOnDate: day}
+	}
+
+	acc.Days[d].add(t)
+	acc.Days[d].SummaryOnDate = day
+	acc.Summary.add(t)
+	r.Summary.add(t)
 }
 
 type TransferTransaction struct {
@@ -38,9 +378,48 @@ type TransferTransaction struct {
 	ToAccountNumber   string
 	Currency          string
 	Amount            float64
+	QuoteID           string
+}
+
+// limite de itens em um lote de TransferMultiple, todos gravados na mesma transação
+const MaxTransferBatchSize = 100
+
+// falha de um item do lote, que desfaz todas as transferências do lote
+type TransferBatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *TransferBatchError) Error() string {
+	return fmt.Sprintf("transfer batch item %d failed: %v", e.Index, e.Err)
+}
+
+func (e *TransferBatchError) Unwrap() error {
+	return e.Err
 }
 
 var ErrTransferSourceAccountNotFound = errors.New("source account not found")
 var ErrTransferDestinationAccountNotFound = errors.New("destination account not found")
 var ErrTransferRecordFailed = errors.New("cant create transfer record")
 var ErrTransferTransactionPair = errors.New("cant create transfer transaction pair. Possibly insufficient balance")
+var ErrTransactionAccountNotFound = errors.New("account not found")
+var ErrTransactionInvalidType = errors.New("transaction type must be IN or OUT")
+var ErrTransactionInvalidAmount = errors.New("transaction amount must be positive")
+var ErrTransactionInsufficientFunds = errors.New("insufficient funds")
+var ErrTransferInvalidAmount = errors.New("transfer amount must be positive")
+var ErrTransferBatchTooLarge = errors.New("transfer batch too large")
+var ErrTransferBatchNotCommitted = errors.New("transfer batch not committed")
+var ErrTransferBatchRolledBack = errors.New("transfer batch rolled back")
+
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+var ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+var ErrExchangeRateDeltaTooLarge = errors.New("exchange rate delta exceeds allowed variation")
+var ErrInvalidTimeRange = errors.New("end timestamp must be after start timestamp")
+var ErrInvalidCandleInterval = errors.New("invalid candle interval")
+var ErrInvalidPageToken = errors.New("invalid page token")
+var ErrExchangeRateSubscriberTooSlow = errors.New("exchange rate subscriber is too slow, updates were dropped")
+var ErrExchangeSpreadNotFound = errors.New("exchange spread not found")
+var ErrExchangeQuoteNotFound = errors.New("exchange quote not found")
+var ErrExchangeQuoteExpired = errors.New("exchange quote expired")
+var ErrExchangeQuoteUsed = errors.New("exchange quote already used")
+var ErrExchangeQuoteMismatch = errors.New("exchange quote currencies do not match transfer accounts")
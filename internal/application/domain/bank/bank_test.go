@@ -0,0 +1,106 @@
+package bank
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestResolveMidRate(t *testing.T) {
+	rates := map[[2]string]float64{
+		{"USD", "BRL"}: 5.0,
+		{"USD", "EUR"}: 0.8,
+		{"GBP", "USD"}: 1.25,
+	}
+
+	lookup := func(from, to string) (float64, error) {
+		if rate, ok := rates[[2]string{from, to}]; ok {
+			return rate, nil
+		}
+
+		return 0, ErrExchangeRateNotFound
+	}
+
+	tests := []struct {
+		name       string
+		from, to   string
+		wantRate   float64
+		wantSource string
+		wantErr    error
+	}{
+		{name: "same currency", from: "BRL", to: "BRL", wantRate: 1, wantSource: ExchangeRateSourceDirect},
+		{name: "direct", from: "USD", to: "BRL", wantRate: 5.0, wantSource: ExchangeRateSourceDirect},
+		{name: "inverse", from: "BRL", to: "USD", wantRate: 0.2, wantSource: ExchangeRateSourceInverse},
+		// EUR -> USD (inversa de 0.8) e USD -> BRL (direta)
+		{name: "cross through base", from: "EUR", to: "BRL", wantRate: 1.25 * 5.0, wantSource: ExchangeRateSourceCross},
+		// GBP -> USD (direta) e USD -> EUR (direta)
+		{name: "cross with direct legs", from: "GBP", to: "EUR", wantRate: 1.25 * 0.8, wantSource: ExchangeRateSourceCross},
+		{name: "missing pair with base", from: "USD", to: "JPY", wantErr: ErrExchangeRateNotFound},
+		{name: "missing cross leg", from: "BRL", to: "JPY", wantErr: ErrExchangeRateNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rate, source, err := ResolveMidRate(tc.from, tc.to, DefaultBaseCurrency, lookup)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("got %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ResolveMidRate: %v", err)
+			}
+
+			if !approxEqual(rate, tc.wantRate) || source != tc.wantSource {
+				t.Errorf("got (%v, %v), want (%v, %v)", rate, source, tc.wantRate, tc.wantSource)
+			}
+		})
+	}
+}
+
+func TestResolveMidRateStopsOnLookupErrors(t *testing.T) {
+	dbErr := errors.New("connection reset")
+	lookup := func(from, to string) (float64, error) {
+		return 0, dbErr
+	}
+
+	if _, _, err := ResolveMidRate("EUR", "BRL", DefaultBaseCurrency, lookup); !errors.Is(err, dbErr) {
+		t.Errorf("got %v, want the lookup error", err)
+	}
+}
+
+func TestNewExchangeRatePrice(t *testing.T) {
+	tests := []struct {
+		name     string
+		mid      float64
+		spread   float64
+		wantBuy  float64
+		wantSell float64
+	}{
+		{name: "default spread", mid: 5.0, spread: DefaultExchangeSpread, wantBuy: 4.975, wantSell: 5.025},
+		{name: "standard tier", mid: 5.0, spread: 0.02, wantBuy: 4.95, wantSell: 5.05},
+		{name: "premium tier", mid: 5.0, spread: 0.004, wantBuy: 4.99, wantSell: 5.01},
+		{name: "no spread", mid: 0.2, spread: 0, wantBuy: 0.2, wantSell: 0.2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewExchangeRatePrice("USD", "BRL", tc.mid, tc.spread, ExchangeRateSourceDirect)
+
+			if !approxEqual(p.BuyRate, tc.wantBuy) || !approxEqual(p.SellRate, tc.wantSell) {
+				t.Errorf("got buy %v and sell %v, want %v and %v", p.BuyRate, p.SellRate, tc.wantBuy, tc.wantSell)
+			}
+
+			// o spread é dividido igualmente em torno da média
+			if !approxEqual(p.MidRate-p.BuyRate, p.SellRate-p.MidRate) || !approxEqual(p.SellRate-p.BuyRate, tc.mid*tc.spread) {
+				t.Errorf("spread %v not split evenly around %v: buy %v, sell %v", tc.spread, tc.mid, p.BuyRate, p.SellRate)
+			}
+		})
+	}
+}
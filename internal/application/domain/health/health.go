@@ -0,0 +1,24 @@
+package health
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrRateFeedStale = errors.New("exchange rate feed is stale")
+	ErrRateFeedEmpty = errors.New("no exchange rate received yet")
+)
+
+// resultado de uma verificação das dependências do serviço bank. Erros nil indicam dependência saudável
+type Report struct {
+	CheckedAt       time.Time
+	DatabaseErr     error
+	RateFeedErr     error
+	LastRateAt      time.Time
+	DatabaseLatency time.Duration
+}
+
+func (r Report) Healthy() bool {
+	return r.DatabaseErr == nil && r.RateFeedErr == nil
+}
@@ -0,0 +1,118 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DeliveryStatusPending   string = "PENDING"
+	DeliveryStatusDelivered string = "DELIVERED"
+	DeliveryStatusFailed    string = "FAILED"
+)
+
+// inscrição em todos os tipos de evento da conta
+const EventTypeAll string = "*"
+
+const (
+	DefaultMaxAttempts int           = 8
+	RetryBaseDelay     time.Duration = 5 * time.Second
+	RetryMaxDelay      time.Duration = time.Hour
+)
+
+// headers enviados em cada entrega. A assinatura é "t=<unix>,v1=<hex>", onde v1 é o
+// HMAC-SHA256 com o secret da inscrição sobre "<unix>.<body>"
+const (
+	HeaderSignature  string = "X-Webhook-Signature"
+	HeaderTimestamp  string = "X-Webhook-Timestamp"
+	HeaderEventID    string = "X-Webhook-Event-Id"
+	HeaderEventType  string = "X-Webhook-Event-Type"
+	HeaderDeliveryID string = "X-Webhook-Delivery-Id"
+)
+
+type Subscription struct {
+	SubscriptionUUID uuid.UUID
+	AccountNumber    string
+	EventType        string
+	URL              string
+	Secret           string
+	Active           bool
+	CreatedAt        time.Time
+}
+
+type Delivery struct {
+	DeliveryUUID     uuid.UUID
+	SubscriptionUUID uuid.UUID
+	EventUUID        uuid.UUID
+	EventType        string
+	Payload          []byte
+	Status           string
+	Attempts         int
+	LastStatusCode   int
+	LastError        string
+	NextAttemptAt    time.Time
+	DeliveredAt      *time.Time
+	CreatedAt        time.Time
+}
+
+// corpo enviado ao parceiro, o mesmo em todas as tentativas e replays
+type Envelope struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+var ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+var ErrDeliveryNotFound = errors.New("webhook delivery not found")
+var ErrInvalidURL = errors.New("invalid webhook url")
+var ErrForbiddenDestination = errors.New("webhook url resolves to a non-public address")
+var ErrInvalidEventType = errors.New("invalid webhook event type")
+var ErrInvalidDeliveryStatus = errors.New("invalid webhook delivery status")
+
+// faixas fora de IsPrivate/IsLoopback/IsLinkLocal* que também não são roteáveis na internet
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+}
+
+// só endereços públicos recebem entregas, para uma inscrição não alcançar a rede interna
+// (metadata da cloud, serviços em localhost, etc.). Endereços IPv4 mapeados em IPv6 são
+// avaliados como IPv4
+func IsAllowedDestination(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+
+	for _, p := range reservedPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// assinatura no formato do header X-Webhook-Signature, o receptor recalcula com o mesmo
+// secret e compara, rejeitando timestamps antigos para evitar replay
+func Sign(secret string, ts time.Time, body []byte) string {
+	unix := strconv.FormatInt(ts.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,307 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+const (
+	DefaultSubscriberBufferSize = 16
+	// quantas atualizações seguidas podem ser descartadas antes de desconectar o subscriber
+	DefaultMaxDroppedUpdates = 64
+	// janelas de validade guardadas por par. Os providers podem gravar a próxima janela antes dela começar
+	maxCachedRateWindows = 4
+)
+
+type exchangeRateTopic struct {
+	fromCurrency string
+	toCurrency   string
+	customerTier string
+}
+
+type exchangeRateTopicState struct {
+	current     *bank.ExchangeRatePrice
+	subscribers map[*ExchangeRateSubscription]struct{}
+}
+
+// hub em memória das taxas de câmbio. Cada par/tier é resolvido uma única vez por atualização
+// e o resultado é distribuído para todas as streams inscritas, em vez de cada stream consultar o banco.
+// As taxas publicadas ficam em cache e os tópicos são precificados a partir dele; o banco só é
+// consultado na primeira inscrição do tópico e quando falta no cache alguma perna do par
+type ExchangeRateHub struct {
+	bankService       port.BankServicePort
+	baseCurrency      string
+	bufferSize        int
+	maxDroppedUpdates int
+
+	mu     sync.Mutex
+	topics map[exchangeRateTopic]*exchangeRateTopicState
+	latest map[string][]bank.ExchangeRate
+	// quando chegou a última taxa nova, usado pelo health check do feed
+	lastPublishedAt time.Time
+}
+
+func NewExchangeRateHub(bankService port.BankServicePort) *ExchangeRateHub {
+	return &ExchangeRateHub{
+		bankService:       bankService,
+		baseCurrency:      bank.DefaultBaseCurrency,
+		bufferSize:        DefaultSubscriberBufferSize,
+		maxDroppedUpdates: DefaultMaxDroppedUpdates,
+		topics:            make(map[exchangeRateTopic]*exchangeRateTopicState),
+		latest:            make(map[string][]bank.ExchangeRate),
+	}
+}
+
+type ExchangeRateSubscription struct {
+	hub     *ExchangeRateHub
+	topic   exchangeRateTopic
+	updates chan bank.ExchangeRatePrice
+	done    chan struct{}
+	dropped int
+	err     error
+	once    sync.Once
+}
+
+func (s *ExchangeRateSubscription) Updates() <-chan bank.ExchangeRatePrice {
+	return s.updates
+}
+
+func (s *ExchangeRateSubscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *ExchangeRateSubscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	return s.err
+}
+
+func (s *ExchangeRateSubscription) Close() {
+	s.hub.unsubscribe(s, nil)
+}
+
+func (h *ExchangeRateHub) Subscribe(ctx context.Context, fromCurrency, toCurrency, customerTier string) (port.ExchangeRateSubscriptionPort, error) {
+	if customerTier == "" {
+		customerTier = bank.CustomerTierDefault
+	}
+
+	topic := exchangeRateTopic{
+		fromCurrency: fromCurrency,
+		toCurrency:   toCurrency,
+		customerTier: customerTier,
+	}
+
+	sub := &ExchangeRateSubscription{
+		hub:     h,
+		topic:   topic,
+		updates: make(chan bank.ExchangeRatePrice, h.bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	state, ok := h.topics[topic]
+	if !ok {
+		state = &exchangeRateTopicState{subscribers: make(map[*ExchangeRateSubscription]struct{})}
+		h.topics[topic] = state
+	}
+
+	state.subscribers[sub] = struct{}{}
+	current := state.current
+	h.mu.Unlock()
+
+	// primeiro subscriber do tópico: resolve a taxa atual uma vez para popular o cache
+	if current == nil {
+		price, err := h.bankService.GetExchangeRatePrice(ctx, fromCurrency, toCurrency, customerTier, time.Now())
+		if err != nil && !errors.Is(err, bank.ErrExchangeRateNotFound) {
+			sub.Close()
+			return nil, err
+		}
+
+		if err == nil {
+			h.mu.Lock()
+			if state.current == nil {
+				state.current = &price
+			}
+			current = state.current
+			h.mu.Unlock()
+		}
+	}
+
+	if current != nil {
+		h.mu.Lock()
+		h.enqueue(sub, *current)
+		h.mu.Unlock()
+	}
+
+	return sub, nil
+}
+
+// chamado pelo BankService após gravar uma taxa e pelo listener do postgres para taxas gravadas
+// por outras instâncias. A mesma taxa recebida pelos dois caminhos é ignorada na segunda vez
+func (h *ExchangeRateHub) PublishExchangeRate(r bank.ExchangeRate) {
+	pair := r.FromCurrency + "/" + r.ToCurrency
+	now := time.Now()
+
+	h.mu.Lock()
+	for _, known := range h.latest[pair] {
+		if known.Rate == r.Rate && known.ValidFromTimestamp.Equal(r.ValidFromTimestamp) {
+			h.mu.Unlock()
+			return
+		}
+	}
+
+	h.latest[pair] = cacheRateWindow(h.latest[pair], r, now)
+	h.lastPublishedAt = now
+
+	// uma taxa afeta os tópicos do próprio par, do inverso e dos pares cruzados que compartilham moeda.
+	// O spread do tier não muda com a taxa, então é reaproveitado do preço atual do tópico
+	var misses []exchangeRateTopic
+	for topic, state := range h.topics {
+		if len(state.subscribers) == 0 {
+			continue
+		}
+
+		if topic.fromCurrency != r.FromCurrency && topic.fromCurrency != r.ToCurrency &&
+			topic.toCurrency != r.FromCurrency && topic.toCurrency != r.ToCurrency {
+			continue
+		}
+
+		if state.current == nil {
+			misses = append(misses, topic)
+			continue
+		}
+
+		midRate, source, err := bank.ResolveMidRate(topic.fromCurrency, topic.toCurrency, h.baseCurrency, h.cachedRateLocked(now))
+		if err != nil {
+			misses = append(misses, topic)
+			continue
+		}
+
+		h.setPriceLocked(state, bank.NewExchangeRatePrice(topic.fromCurrency, topic.toCurrency, midRate, state.current.Spread, source))
+	}
+	h.mu.Unlock()
+
+	// a distribuição não pertence a nenhuma RPC, então não leva request id
+	ctx := context.Background()
+
+	for _, topic := range misses {
+		price, err := h.bankService.GetExchangeRatePrice(ctx, topic.fromCurrency, topic.toCurrency, topic.customerTier, now)
+		if err != nil {
+			if !errors.Is(err, bank.ErrExchangeRateNotFound) {
+				logger.ErrorContext(ctx, "failed to resolve exchange rate", "from_currency", topic.fromCurrency, "to_currency", topic.toCurrency, "err", err)
+			}
+			continue
+		}
+
+		h.mu.Lock()
+		if state, ok := h.topics[topic]; ok {
+			h.setPriceLocked(state, price)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// lookup de ResolveMidRate sobre o cache: a janela do par válida em ts. Deve ser usado com h.mu travado
+func (h *ExchangeRateHub) cachedRateLocked(ts time.Time) func(from, to string) (float64, error) {
+	return func(from, to string) (float64, error) {
+		windows := h.latest[from+"/"+to]
+		for i := len(windows) - 1; i >= 0; i-- {
+			r := windows[i]
+			if !ts.Before(r.ValidFromTimestamp) && !ts.After(r.ValidToTimestamp) {
+				return r.Rate, nil
+			}
+		}
+
+		return 0, bank.ErrExchangeRateNotFound
+	}
+}
+
+func (h *ExchangeRateHub) setPriceLocked(state *exchangeRateTopicState, price bank.ExchangeRatePrice) {
+	state.current = &price
+	for sub := range state.subscribers {
+		h.enqueue(sub, price)
+	}
+}
+
+// descarta as janelas já expiradas e a janela substituída por r, e mantém só as maxCachedRateWindows mais recentes
+func cacheRateWindow(windows []bank.ExchangeRate, r bank.ExchangeRate, now time.Time) []bank.ExchangeRate {
+	res := make([]bank.ExchangeRate, 0, len(windows)+1)
+	for _, w := range windows {
+		if !w.ValidToTimestamp.Before(now) && !w.ValidFromTimestamp.Equal(r.ValidFromTimestamp) {
+			res = append(res, w)
+		}
+	}
+
+	res = append(res, r)
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ValidFromTimestamp.Before(res[j].ValidFromTimestamp)
+	})
+
+	if len(res) > maxCachedRateWindows {
+		res = res[len(res)-maxCachedRateWindows:]
+	}
+
+	return res
+}
+
+func (h *ExchangeRateHub) LastPublishedAt() time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.lastPublishedAt
+}
+
+// deve ser chamado com h.mu travado. Com o buffer cheio descarta a atualização mais antiga,
+// já que só a taxa mais recente importa, e desconecta quem acumula descartes demais
+func (h *ExchangeRateHub) enqueue(sub *ExchangeRateSubscription, price bank.ExchangeRatePrice) {
+	select {
+	case sub.updates <- price:
+		sub.dropped = 0
+		return
+	default:
+	}
+
+	select {
+	case <-sub.updates:
+	default:
+	}
+
+	sub.updates <- price
+	sub.dropped++
+
+	if sub.dropped > h.maxDroppedUpdates {
+		logger.Warn("disconnecting slow exchange rate subscriber", "from_currency", sub.topic.fromCurrency, "to_currency", sub.topic.toCurrency)
+		h.removeLocked(sub, bank.ErrExchangeRateSubscriberTooSlow)
+	}
+}
+
+func (h *ExchangeRateHub) unsubscribe(sub *ExchangeRateSubscription, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeLocked(sub, err)
+}
+
+func (h *ExchangeRateHub) removeLocked(sub *ExchangeRateSubscription, err error) {
+	sub.once.Do(func() {
+		sub.err = err
+		close(sub.done)
+
+		state, ok := h.topics[sub.topic]
+		if !ok {
+			return
+		}
+
+		delete(state.subscribers, sub)
+		if len(state.subscribers) == 0 {
+			delete(h.topics, sub.topic)
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+// só GetExchangeRatePrice é usado pelo hub. Responde com taxas fixas e conta as consultas
+type priceCountingBankService struct {
+	port.BankServicePort
+
+	mu    sync.Mutex
+	calls int
+	rates map[string]float64
+}
+
+func (s *priceCountingBankService) GetExchangeRatePrice(ctx context.Context, fromCurrency, toCurrency, customerTier string,
+	ts time.Time) (bank.ExchangeRatePrice, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls++
+
+	rate, ok := s.rates[fromCurrency+"/"+toCurrency]
+	if !ok {
+		return bank.ExchangeRatePrice{}, bank.ErrExchangeRateNotFound
+	}
+
+	return bank.NewExchangeRatePrice(fromCurrency, toCurrency, rate, 0.02, bank.ExchangeRateSourceDirect), nil
+}
+
+func (s *priceCountingBankService) callCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.calls
+}
+
+func nextUpdate(t *testing.T, sub port.ExchangeRateSubscriptionPort) bank.ExchangeRatePrice {
+	t.Helper()
+
+	select {
+	case price := <-sub.Updates():
+		return price
+	case <-time.After(time.Second):
+		t.Fatal("no update received")
+		return bank.ExchangeRatePrice{}
+	}
+}
+
+func TestHubPricesTopicsFromCache(t *testing.T) {
+	bs := &priceCountingBankService{rates: map[string]float64{"USD/BRL": 5.8, "BRL/USD": 1 / 5.8}}
+	hub := NewExchangeRateHub(bs)
+
+	direct, err := hub.Subscribe(context.Background(), "USD", "BRL", bank.CustomerTierDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer direct.Close()
+
+	inverse, err := hub.Subscribe(context.Background(), "BRL", "USD", bank.CustomerTierDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer inverse.Close()
+
+	nextUpdate(t, direct)
+	nextUpdate(t, inverse)
+
+	// uma consulta por tópico na inscrição
+	if calls := bs.callCount(); calls != 2 {
+		t.Fatalf("got %d price lookups after subscribing, want 2", calls)
+	}
+
+	now := time.Now()
+	for i, rate := range []float64{5.9, 6.0, 6.1} {
+		hub.PublishExchangeRate(bank.ExchangeRate{
+			FromCurrency:       "USD",
+			ToCurrency:         "BRL",
+			Rate:               rate,
+			ValidFromTimestamp: now.Add(-time.Minute + time.Duration(i)*time.Millisecond),
+			ValidToTimestamp:   now.Add(time.Minute),
+		})
+
+		got := nextUpdate(t, direct)
+		if got.MidRate != rate || got.Source != bank.ExchangeRateSourceDirect || got.Spread != 0.02 {
+			t.Errorf("direct price %+v, want mid rate %v with the subscribed spread", got, rate)
+		}
+
+		got = nextUpdate(t, inverse)
+		if math.Abs(got.MidRate-1/rate) > 1e-12 || got.Source != bank.ExchangeRateSourceInverse {
+			t.Errorf("inverse price %+v, want mid rate %v", got, 1/rate)
+		}
+	}
+
+	if calls := bs.callCount(); calls != 2 {
+		t.Errorf("got %d price lookups after publishing, want 2", calls)
+	}
+}
+
+func TestHubFallsBackToBankServiceOnCacheMiss(t *testing.T) {
+	bs := &priceCountingBankService{rates: map[string]float64{"EUR/BRL": 6.3}}
+	hub := NewExchangeRateHub(bs)
+
+	cross, err := hub.Subscribe(context.Background(), "EUR", "BRL", bank.CustomerTierDefault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cross.Close()
+
+	nextUpdate(t, cross)
+
+	// a perna USD/EUR não está no cache, então o tópico cruzado vai ao banco
+	now := time.Now()
+	hub.PublishExchangeRate(bank.ExchangeRate{
+		FromCurrency:       "USD",
+		ToCurrency:         "BRL",
+		Rate:               5.8,
+		ValidFromTimestamp: now,
+		ValidToTimestamp:   now.Add(time.Minute),
+	})
+
+	nextUpdate(t, cross)
+
+	if calls := bs.callCount(); calls != 2 {
+		t.Errorf("got %d price lookups, want 2", calls)
+	}
+}
+
+func TestHubIgnoresFutureWindowUntilValid(t *testing.T) {
+	now := time.Now()
+	current := bank.ExchangeRate{FromCurrency: "USD", ToCurrency: "BRL", Rate: 5.8,
+		ValidFromTimestamp: now.Add(-time.Minute), ValidToTimestamp: now.Add(time.Minute)}
+	next := bank.ExchangeRate{FromCurrency: "USD", ToCurrency: "BRL", Rate: 5.9,
+		ValidFromTimestamp: now.Add(time.Minute), ValidToTimestamp: now.Add(2 * time.Minute)}
+
+	windows := cacheRateWindow(cacheRateWindow(nil, current, now), next, now)
+
+	hub := NewExchangeRateHub(&priceCountingBankService{})
+	hub.latest["USD/BRL"] = windows
+
+	rate, err := hub.cachedRateLocked(now)("USD", "BRL")
+	if err != nil || rate != 5.8 {
+		t.Errorf("rate at now: %v %v, want 5.8", rate, err)
+	}
+
+	rate, err = hub.cachedRateLocked(now.Add(90*time.Second))("USD", "BRL")
+	if err != nil || rate != 5.9 {
+		t.Errorf("rate in the next window: %v %v, want 5.9", rate, err)
+	}
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+type ExchangeRateImporter struct {
+	provider    port.ExchangeRateProviderPort
+	bankService port.BankServicePort
+}
+
+func NewExchangeRateImporter(provider port.ExchangeRateProviderPort, bankService port.BankServicePort) *ExchangeRateImporter {
+	return &ExchangeRateImporter{
+		provider:    provider,
+		bankService: bankService,
+	}
+}
+
+// busca as taxas no provider e grava uma a uma pelo BankService, que valida cada taxa.
+// Taxas inválidas são descartadas sem interromper o restante da importação e só as gravadas
+// são confirmadas para o provider
+func (i *ExchangeRateImporter) Import(ctx context.Context) (int, error) {
+	rates, err := i.provider.FetchExchangeRates(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	imported := make([]bank.ExchangeRate, 0, len(rates))
+	var errs []error
+
+	for _, r := range rates {
+		if _, err := i.bankService.CreateExchangeRate(ctx, r); err != nil {
+			logger.WarnContext(ctx, "rejected exchange rate", "provider", i.provider.Name(),
+				"from_currency", r.FromCurrency, "to_currency", r.ToCurrency, "err", err)
+			errs = append(errs, err)
+			continue
+		}
+
+		imported = append(imported, r)
+	}
+
+	if ack, ok := i.provider.(port.ExchangeRateAckPort); ok {
+		ack.AckExchangeRates(imported)
+	}
+
+	return len(imported), errors.Join(errs...)
+}
+
+func (i *ExchangeRateImporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// a primeira importação é feita na partida, sem esperar um intervalo sem taxas
+	for {
+		imported, err := i.Import(ctx)
+		if err != nil {
+			logger.WarnContext(ctx, "exchange rate import finished with errors", "provider", i.provider.Name(), "err", err)
+		}
+
+		if imported > 0 {
+			logger.InfoContext(ctx, "imported exchange rates", "provider", i.provider.Name(), "count", imported)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
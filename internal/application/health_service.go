@@ -0,0 +1,52 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/health"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+type HealthService struct {
+	db          port.HealthDatabasePort
+	rateHub     port.ExchangeRateHubPort
+	pingTimeout time.Duration
+	maxRateAge  time.Duration
+}
+
+// maxRateAge 0 desliga a verificação do feed de taxas
+func NewHealthService(db port.HealthDatabasePort, rateHub port.ExchangeRateHubPort, pingTimeout, maxRateAge time.Duration) *HealthService {
+	return &HealthService{
+		db:          db,
+		rateHub:     rateHub,
+		pingTimeout: pingTimeout,
+		maxRateAge:  maxRateAge,
+	}
+}
+
+func (s *HealthService) Check(ctx context.Context) health.Report {
+	report := health.Report{CheckedAt: time.Now()}
+
+	pingCtx, cancel := context.WithTimeout(ctx, s.pingTimeout)
+	defer cancel()
+
+	if err := s.db.Ping(pingCtx); err != nil {
+		report.DatabaseErr = fmt.Errorf("failed to ping database: %w", err)
+	}
+	report.DatabaseLatency = time.Since(report.CheckedAt)
+
+	if s.maxRateAge > 0 {
+		report.LastRateAt = s.rateHub.LastPublishedAt()
+
+		switch {
+		case report.LastRateAt.IsZero():
+			report.RateFeedErr = health.ErrRateFeedEmpty
+		case report.CheckedAt.Sub(report.LastRateAt) > s.maxRateAge:
+			report.RateFeedErr = fmt.Errorf("%w: last rate at %v", health.ErrRateFeedStale, report.LastRateAt.Format(time.RFC3339))
+		}
+	}
+
+	return report
+}
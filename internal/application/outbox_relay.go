@@ -0,0 +1,143 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+const (
+	DefaultOutboxBatchSize   = 100
+	DefaultOutboxMaxAttempts = 10
+	outboxRetryBaseDelay     = time.Second
+	outboxRetryMaxDelay      = 5 * time.Minute
+)
+
+// publica os eventos do outbox em ordem de sequência. Um evento com falha bloqueia os seguintes
+// até ser publicado ou ir para a dead letter (entrega at-least-once). Só uma instância publica por
+// vez, e a ordem garantida ao consumidor é a AggregateVersion dentro de cada agregado
+type OutboxRelay struct {
+	db          port.OutboxDatabasePort
+	sink        port.OutboxSinkPort
+	batchSize   int
+	maxAttempts int
+}
+
+func NewOutboxRelay(db port.OutboxDatabasePort, sink port.OutboxSinkPort) *OutboxRelay {
+	return &OutboxRelay{
+		db:          db,
+		sink:        sink,
+		batchSize:   DefaultOutboxBatchSize,
+		maxAttempts: DefaultOutboxMaxAttempts,
+	}
+}
+
+func (r *OutboxRelay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			published, err := r.RelayPending(ctx)
+			if err != nil {
+				logger.ErrorContext(ctx, "outbox relay failed", "sink", r.sink.Name(), "err", err)
+			}
+
+			if published > 0 {
+				logger.InfoContext(ctx, "published outbox events", "sink", r.sink.Name(), "count", published)
+			}
+		}
+	}
+}
+
+// outra instância segurando o lock não é erro: o lote fica para ela e este ciclo não publica nada
+func (r *OutboxRelay) RelayPending(ctx context.Context) (int, error) {
+	published := 0
+
+	acquired, err := r.db.WithOutboxRelayLock(ctx, func() error {
+		var err error
+		published, err = r.relayBatch(ctx)
+
+		return err
+	})
+	if err != nil {
+		return published, err
+	}
+
+	if !acquired {
+		logger.DebugContext(ctx, "outbox relay lock held by another instance", "sink", r.sink.Name())
+	}
+
+	return published, nil
+}
+
+func (r *OutboxRelay) relayBatch(ctx context.Context) (int, error) {
+	eventsOrm, err := r.db.GetPendingOutboxEvents(r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	now := time.Now()
+
+	for _, e := range eventsOrm {
+		// evento aguardando o backoff, os seguintes esperam junto para manter a ordem
+		if e.NextAttemptAt.After(now) {
+			break
+		}
+
+		event := bank.OutboxEvent{
+			Sequence:         e.OutboxSequence,
+			EventUUID:        e.EventUUID,
+			AggregateType:    e.AggregateType,
+			AggregateUUID:    e.AggregateUUID,
+			AggregateVersion: e.AggregateVersion,
+			EventType:        e.EventType,
+			Payload:          []byte(e.Payload),
+			Attempts:         e.Attempts,
+			CreatedAt:        e.CreatedAt,
+		}
+
+		if err := r.sink.Publish(ctx, event); err != nil {
+			if e.Attempts+1 >= r.maxAttempts {
+				logger.ErrorContext(ctx, "outbox event moved to dead letter", "sink", r.sink.Name(),
+					"event_uuid", e.EventUUID, "attempts", e.Attempts+1, "err", err)
+
+				if err := r.db.MoveOutboxEventToDeadLetter(e, err.Error()); err != nil {
+					return published, err
+				}
+
+				continue
+			}
+
+			if err := r.db.MarkOutboxEventFailed(e.OutboxSequence, err.Error(), now.Add(outboxRetryDelay(e.Attempts))); err != nil {
+				return published, err
+			}
+
+			return published, err
+		}
+
+		if err := r.db.MarkOutboxEventPublished(e.OutboxSequence); err != nil {
+			return published, err
+		}
+
+		published++
+	}
+
+	return published, nil
+}
+
+// backoff exponencial: 1s, 2s, 4s... limitado a 5 minutos
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := outboxRetryBaseDelay << attempts
+	if delay <= 0 || delay > outboxRetryMaxDelay {
+		return outboxRetryMaxDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// buckets sem uso por esse tempo já estão cheios e são descartados para o mapa não crescer sem limite
+const idleBucketTTL = 10 * time.Minute
+
+// token bucket: enche rate tokens por segundo até burst, cada chamada consome um token
+type bucket struct {
+	tokens   float64
+	last     time.Time
+	lastUsed time.Time
+}
+
+// um token bucket por chave (identidade, peer ou chave de API) com a mesma taxa e burst
+type Limiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewLimiter(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = int(math.Max(1, math.Ceil(rate)))
+	}
+
+	return &Limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// consome um token da chave. Sem token disponível devolve false e quanto esperar pelo próximo
+func (l *Limiter) Allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.lastUsed = now
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(l.burst, b.tokens+elapsed.Seconds()*l.rate)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < idleBucketTTL {
+		return
+	}
+
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.lastUsed) >= idleBucketTTL {
+			delete(l.buckets, key)
+		}
+	}
+}
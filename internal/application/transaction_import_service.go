@@ -0,0 +1,155 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/adapter/database"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+// importa transações históricas em lote: valida cada linha, acumula as válidas e grava em
+// lotes com CreateTransactionsBulk. Linhas inválidas são reportadas sem interromper a importação
+type TransactionImportService struct {
+	db        port.BankDatabasePort
+	batchSize int
+}
+
+func NewTransactionImportService(db port.BankDatabasePort, batchSize int) *TransactionImportService {
+	if batchSize <= 0 {
+		batchSize = bank.DefaultImportBatchSize
+	}
+
+	return &TransactionImportService{
+		db:        db,
+		batchSize: batchSize,
+	}
+}
+
+// em dry-run nada é gravado e RowsImported conta as linhas que passaram na validação
+func (s *TransactionImportService) ImportTransactions(ctx context.Context, src port.TransactionSourcePort, dryRun bool) (bank.TransactionImportResult, error) {
+	res := bank.TransactionImportResult{DryRun: dryRun}
+	accounts := make(map[string]*database.BankAccountOrm)
+	now := time.Now()
+
+	batch := make([]database.BankTransactionOrm, 0, s.batchSize)
+	batchRows := make([]int, 0, s.batchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+
+		if dryRun {
+			res.RowsImported += len(batch)
+		} else if err := s.db.CreateTransactionsBulk(ctx, batch); err != nil {
+			// o lote é gravado em uma transação, então todas as linhas dele falham juntas
+			logger.ErrorContext(ctx, "failed to import transaction batch", "rows", len(batch), "err", err)
+
+			for _, row := range batchRows {
+				res.AddError(bank.TransactionImportError{Row: row, Message: err.Error()})
+			}
+		} else {
+			res.RowsImported += len(batch)
+		}
+
+		batch = batch[:0]
+		batchRows = batchRows[:0]
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+
+		t, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rowErr *bank.TransactionImportError
+		if errors.As(err, &rowErr) {
+			res.RowsRead++
+			res.AddError(*rowErr)
+			continue
+		}
+
+		if err != nil {
+			return res, err
+		}
+
+		res.RowsRead++
+
+		transactionOrm, rowErr := s.validateImportedTransaction(ctx, t, accounts, now)
+		if rowErr != nil {
+			res.AddError(*rowErr)
+			continue
+		}
+
+		batch = append(batch, transactionOrm)
+		batchRows = append(batchRows, t.Row)
+
+		if len(batch) >= s.batchSize {
+			flush()
+		}
+	}
+
+	flush()
+
+	return res, nil
+}
+
+func (s *TransactionImportService) validateImportedTransaction(ctx context.Context, t bank.ImportedTransaction, accounts map[string]*database.BankAccountOrm,
+	now time.Time) (database.BankTransactionOrm, *bank.TransactionImportError) {
+	invalid := func(field, message string) (database.BankTransactionOrm, *bank.TransactionImportError) {
+		return database.BankTransactionOrm{}, &bank.TransactionImportError{Row: t.Row, Field: field, Message: message}
+	}
+
+	if t.AccountNumber == "" {
+		return invalid("account_number", "account number is required")
+	}
+
+	// contas consultadas uma vez por importação, nil marca conta inexistente
+	acc, ok := accounts[t.AccountNumber]
+	if !ok {
+		accOrm, err := s.db.GetBankAccountNumber(ctx, t.AccountNumber)
+		if err == nil {
+			acc = &accOrm
+		}
+
+		accounts[t.AccountNumber] = acc
+	}
+
+	if acc == nil {
+		return invalid("account_number", fmt.Sprintf("account %v not found", t.AccountNumber))
+	}
+
+	if t.TransactionType != bank.TransactionTypeIn && t.TransactionType != bank.TransactionTypeOut {
+		return invalid("transaction_type", fmt.Sprintf("transaction type %q must be IN or OUT", t.TransactionType))
+	}
+
+	if t.Amount <= 0 || math.IsNaN(t.Amount) || math.IsInf(t.Amount, 0) || t.Amount >= bank.MaxImportTransactionValue {
+		return invalid("amount", fmt.Sprintf("amount %v must be positive and below %v", t.Amount, bank.MaxImportTransactionValue))
+	}
+
+	if t.Timestamp.IsZero() || t.Timestamp.After(now) {
+		return invalid("timestamp", "timestamp must be set and not in the future")
+	}
+
+	return database.BankTransactionOrm{
+		TransactionUUID:      uuid.New(),
+		AccountUUID:          acc.AccountUUID,
+		TransactionTimestamp: t.Timestamp,
+		Amount:               t.Amount,
+		TransactionType:      t.TransactionType,
+		Notes:                t.Notes,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}, nil
+}
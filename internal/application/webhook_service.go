@@ -0,0 +1,377 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/adapter/database"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/webhook"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+)
+
+const DefaultWebhookBatchSize = 100
+
+// transforma eventos do outbox em entregas por inscrição e envia com retry. O evento só
+// gera as entregas, o envio acontece no worker para um parceiro lento não travar o relay
+type WebhookService struct {
+	db          port.WebhookDatabasePort
+	sender      port.WebhookSenderPort
+	batchSize   int
+	maxAttempts int
+}
+
+func NewWebhookService(db port.WebhookDatabasePort, sender port.WebhookSenderPort) *WebhookService {
+	return &WebhookService{
+		db:          db,
+		sender:      sender,
+		batchSize:   DefaultWebhookBatchSize,
+		maxAttempts: webhook.DefaultMaxAttempts,
+	}
+}
+
+func (s *WebhookService) CreateSubscription(ctx context.Context, accountNumber, eventType, rawURL string) (webhook.Subscription, error) {
+	if !isWebhookEventType(eventType) {
+		return webhook.Subscription{}, webhook.ErrInvalidEventType
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return webhook.Subscription{}, webhook.ErrInvalidURL
+	}
+
+	// o sender confere de novo a cada conexão, o DNS pode mudar depois da inscrição
+	if err := s.sender.CheckDestination(ctx, u.String()); err != nil {
+		logger.WarnContext(ctx, "webhook destination rejected", "host", u.Hostname(), "err", err)
+		return webhook.Subscription{}, err
+	}
+
+	account, err := s.db.GetBankAccountNumber(ctx, accountNumber)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	now := time.Now()
+	subOrm := database.WebhookSubscriptionOrm{
+		SubscriptionUUID: uuid.New(),
+		AccountUUID:      account.AccountUUID,
+		EventType:        eventType,
+		URL:              u.String(),
+		Secret:           secret,
+		Active:           true,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	id, err := s.db.CreateWebhookSubscription(subOrm)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	// o secret só é devolvido na criação, o parceiro usa para validar as assinaturas
+	return webhook.Subscription{
+		SubscriptionUUID: id,
+		AccountNumber:    account.AccountNumber,
+		EventType:        eventType,
+		URL:              subOrm.URL,
+		Secret:           secret,
+		Active:           true,
+		CreatedAt:        now,
+	}, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, subscriptionUUID uuid.UUID) error {
+	return s.db.DeactivateWebhookSubscription(subscriptionUUID)
+}
+
+// chamado pelo bus do outbox. Reentregas do mesmo evento não duplicam as entregas
+func (s *WebhookService) HandleEvent(ctx context.Context, event bank.OutboxEvent) error {
+	accountUUIDs, err := webhookEventAccounts(event)
+	if err != nil {
+		return err
+	}
+
+	if len(accountUUIDs) == 0 {
+		return nil
+	}
+
+	subsOrm, err := s.db.GetActiveWebhookSubscriptions(accountUUIDs, event.EventType)
+	if err != nil {
+		return err
+	}
+
+	if len(subsOrm) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(webhook.Envelope{
+		ID:        event.EventUUID,
+		Type:      event.EventType,
+		CreatedAt: event.CreatedAt,
+		Data:      json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	now := time.Now()
+	deliveries := make([]database.WebhookDeliveryOrm, 0, len(subsOrm))
+
+	for _, sub := range subsOrm {
+		deliveries = append(deliveries, database.WebhookDeliveryOrm{
+			DeliveryUUID:     uuid.New(),
+			SubscriptionUUID: sub.SubscriptionUUID,
+			EventUUID:        event.EventUUID,
+			EventType:        event.EventType,
+			Payload:          string(payload),
+			Status:           webhook.DeliveryStatusPending,
+			NextAttemptAt:    now,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		})
+	}
+
+	return s.db.CreateWebhookDeliveries(deliveries)
+}
+
+func (s *WebhookService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			delivered, err := s.DeliverPending(ctx)
+			if err != nil {
+				logger.ErrorContext(ctx, "webhook delivery failed", "err", err)
+			}
+
+			if delivered > 0 {
+				logger.InfoContext(ctx, "delivered webhooks", "count", delivered)
+			}
+		}
+	}
+}
+
+func (s *WebhookService) DeliverPending(ctx context.Context) (int, error) {
+	deliveriesOrm, err := s.db.GetDueWebhookDeliveries(time.Now(), s.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+
+	for _, d := range deliveriesOrm {
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+
+		ok, err := s.deliver(ctx, d)
+		if err != nil {
+			return delivered, err
+		}
+
+		if ok {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+func (s *WebhookService) deliver(ctx context.Context, d database.WebhookDeliveryOrm) (bool, error) {
+	start := time.Now()
+
+	var statusCode int
+	var sendErr error
+
+	if d.Subscription.Active {
+		statusCode, sendErr = s.sender.Send(ctx, d.Subscription.URL, d.Subscription.Secret, toWebhookDelivery(d))
+	} else {
+		sendErr = webhook.ErrSubscriptionNotFound
+	}
+
+	now := time.Now()
+	d.Attempts++
+	d.LastStatusCode = statusCode
+
+	attempt := database.WebhookDeliveryAttemptOrm{
+		AttemptUUID:   uuid.New(),
+		DeliveryUUID:  d.DeliveryUUID,
+		AttemptNumber: d.Attempts,
+		StatusCode:    statusCode,
+		DurationMs:    now.Sub(start).Milliseconds(),
+		AttemptedAt:   start,
+	}
+
+	switch {
+	case sendErr == nil:
+		d.Status = webhook.DeliveryStatusDelivered
+		d.LastError = ""
+		d.DeliveredAt = &now
+	case !d.Subscription.Active || d.Attempts >= s.maxAttempts:
+		d.Status = webhook.DeliveryStatusFailed
+		d.LastError = sendErr.Error()
+		attempt.Error = sendErr.Error()
+		logger.WarnContext(ctx, "webhook delivery failed permanently", "delivery_uuid", d.DeliveryUUID, "attempts", d.Attempts, "err", sendErr)
+	default:
+		d.LastError = sendErr.Error()
+		d.NextAttemptAt = now.Add(webhookRetryDelay(d.Attempts - 1))
+		attempt.Error = sendErr.Error()
+	}
+
+	if err := s.db.RecordWebhookDeliveryAttempt(d, attempt); err != nil {
+		return false, err
+	}
+
+	return sendErr == nil, nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, accountNumber, status string, pageSize int, pageToken string) ([]webhook.Delivery, string, error) {
+	if status != "" && status != webhook.DeliveryStatusPending &&
+		status != webhook.DeliveryStatusDelivered && status != webhook.DeliveryStatusFailed {
+		return nil, "", webhook.ErrInvalidDeliveryStatus
+	}
+
+	account, err := s.db.GetBankAccountNumber(ctx, accountNumber)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pageSize = normalizePageSize(pageSize)
+
+	query := pageQuery(account.AccountUUID, status, pageSize)
+
+	beforeCreatedAt, beforeUUID, err := decodePageToken(pageToken, query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// busca um item a mais para saber se existe próxima página
+	deliveriesOrm, err := s.db.ListWebhookDeliveries(account.AccountUUID, status, beforeCreatedAt, beforeUUID, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextPageToken := ""
+	if len(deliveriesOrm) > pageSize {
+		deliveriesOrm = deliveriesOrm[:pageSize]
+		last := deliveriesOrm[pageSize-1]
+		nextPageToken = encodePageToken(last.CreatedAt, last.DeliveryUUID, query)
+	}
+
+	res := make([]webhook.Delivery, 0, len(deliveriesOrm))
+	for _, d := range deliveriesOrm {
+		res = append(res, toWebhookDelivery(d))
+	}
+
+	return res, nextPageToken, nil
+}
+
+// o replay reenvia o mesmo corpo e event id, o parceiro deduplica pelo X-Webhook-Event-Id
+func (s *WebhookService) ReplayDelivery(ctx context.Context, deliveryUUID uuid.UUID) (webhook.Delivery, error) {
+	if _, err := s.db.GetWebhookDelivery(deliveryUUID); err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	if err := s.db.ResetWebhookDelivery(deliveryUUID, time.Now()); err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	deliveryOrm, err := s.db.GetWebhookDelivery(deliveryUUID)
+	if err != nil {
+		return webhook.Delivery{}, err
+	}
+
+	return toWebhookDelivery(deliveryOrm), nil
+}
+
+func isWebhookEventType(eventType string) bool {
+	switch eventType {
+	case webhook.EventTypeAll, bank.EventTypeTransactionCreated, bank.EventTypeTransferCompleted, bank.EventTypeTransferFailed:
+		return true
+	}
+
+	return false
+}
+
+// contas interessadas no evento: a própria conta ou as duas pontas da transferência
+func webhookEventAccounts(event bank.OutboxEvent) ([]uuid.UUID, error) {
+	switch event.EventType {
+	case bank.EventTypeTransactionCreated:
+		return []uuid.UUID{event.AggregateUUID}, nil
+	case bank.EventTypeTransferCompleted:
+		var payload bank.TransferCompletedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal transfer completed payload: %w", err)
+		}
+
+		return []uuid.UUID{payload.FromAccountUUID, payload.ToAccountUUID}, nil
+	case bank.EventTypeTransferFailed:
+		var payload bank.TransferFailedPayload
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal transfer failed payload: %w", err)
+		}
+
+		// transferências rejeitadas por conta inexistente não têm uma das pontas
+		accounts := make([]uuid.UUID, 0, 2)
+		for _, accountUUID := range []uuid.UUID{payload.FromAccountUUID, payload.ToAccountUUID} {
+			if accountUUID != uuid.Nil {
+				accounts = append(accounts, accountUUID)
+			}
+		}
+
+		return accounts, nil
+	}
+
+	return nil, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// backoff exponencial: 5s, 10s, 20s... limitado a 1 hora
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhook.RetryBaseDelay << attempts
+	if delay <= 0 || delay > webhook.RetryMaxDelay {
+		return webhook.RetryMaxDelay
+	}
+
+	return delay
+}
+
+func toWebhookDelivery(d database.WebhookDeliveryOrm) webhook.Delivery {
+	return webhook.Delivery{
+		DeliveryUUID:     d.DeliveryUUID,
+		SubscriptionUUID: d.SubscriptionUUID,
+		EventUUID:        d.EventUUID,
+		EventType:        d.EventType,
+		Payload:          []byte(d.Payload),
+		Status:           d.Status,
+		Attempts:         d.Attempts,
+		LastStatusCode:   d.LastStatusCode,
+		LastError:        d.LastError,
+		NextAttemptAt:    d.NextAttemptAt,
+		DeliveredAt:      d.DeliveredAt,
+		CreatedAt:        d.CreatedAt,
+	}
+}
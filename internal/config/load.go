@@ -0,0 +1,237 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// prefixo das variáveis de ambiente: a flag -rate-provider vira MYGRPC_RATE_PROVIDER
+const EnvPrefix = "MYGRPC_"
+
+// flag com o caminho do arquivo de configuração, que não faz parte de Config
+const configFlag = "config"
+
+// monta a configuração a partir dos defaults, do arquivo, do ambiente e das flags em args,
+// nessa ordem, e devolve também os argumentos que sobraram (subcomandos)
+func Load(name string, args []string) (*Config, []string, error) {
+	// primeira passada só para descobrir o arquivo e falhar cedo em flags inválidas
+	var path string
+	probe := Default()
+	fs := newFlagSet(name, &probe, &path)
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if path == "" {
+		path = os.Getenv(EnvPrefix + "CONFIG")
+	}
+
+	cfg := Default()
+	if path != "" {
+		if err := loadFile(path, &cfg); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// as flags apontam para cfg, então aplicar o ambiente via Set e depois fazer o Parse
+	// garante que só as flags informadas sobrescrevem o que veio do arquivo
+	fs = newFlagSet(name, &cfg, &path)
+	fs.SetOutput(io.Discard)
+
+	if err := applyEnv(fs); err != nil {
+		return nil, nil, err
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return &cfg, fs.Args(), nil
+}
+
+func newFlagSet(name string, cfg *Config, path *string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+
+	fs.StringVar(path, configFlag, "", "YAML or TOML configuration file (env "+EnvPrefix+"CONFIG)")
+
+	fs.IntVar(&cfg.Grpc.Port, "grpc-port", cfg.Grpc.Port, "gRPC listen port")
+	fs.BoolVar(&cfg.Grpc.Reflection, "grpc-reflection", cfg.Grpc.Reflection, "register gRPC server reflection on the public port")
+	fs.DurationVar(&cfg.Grpc.ShutdownGracePeriod, "grpc-shutdown-grace-period", cfg.Grpc.ShutdownGracePeriod, "time in-flight RPCs get to finish on shutdown")
+	fs.BoolVar(&cfg.Grpc.TLS.Enabled, "tls-enabled", cfg.Grpc.TLS.Enabled, "serve gRPC over TLS")
+	fs.StringVar(&cfg.Grpc.TLS.CertFile, "tls-cert-file", cfg.Grpc.TLS.CertFile, "PEM server certificate chain")
+	fs.StringVar(&cfg.Grpc.TLS.KeyFile, "tls-key-file", cfg.Grpc.TLS.KeyFile, "PEM server private key")
+	fs.StringVar(&cfg.Grpc.TLS.ClientCAFile, "tls-client-ca-file", cfg.Grpc.TLS.ClientCAFile, "PEM CA bundle used to verify client certificates")
+	fs.StringVar(&cfg.Grpc.TLS.ClientAuth, "tls-client-auth", cfg.Grpc.TLS.ClientAuth, "client certificate policy: none, request or require")
+	fs.DurationVar(&cfg.Grpc.TLS.ReloadInterval, "tls-reload-interval", cfg.Grpc.TLS.ReloadInterval, "interval between checks for changed certificate files")
+
+	fs.BoolVar(&cfg.Grpc.Auth.Enabled, "auth-enabled", cfg.Grpc.Auth.Enabled, "require a bearer JWT or API key on every non-public RPC")
+	fs.Var(stringList{&cfg.Grpc.Auth.PublicMethods}, "auth-public-methods", "comma separated methods callable without authentication")
+	fs.BoolVar(&cfg.Grpc.Auth.APIKeys, "auth-api-keys", cfg.Grpc.Auth.APIKeys, "accept API keys from the x-api-key or authorization metadata")
+	fs.StringVar(&cfg.Grpc.Auth.JWT.HMACSecret, "jwt-hmac-secret", cfg.Grpc.Auth.JWT.HMACSecret, "secret for HS256 tokens without kid")
+	fs.StringVar(&cfg.Grpc.Auth.JWT.JWKSFile, "jwt-jwks-file", cfg.Grpc.Auth.JWT.JWKSFile, "local JWKS file with RS256 and HS256 keys")
+	fs.StringVar(&cfg.Grpc.Auth.JWT.Issuer, "jwt-issuer", cfg.Grpc.Auth.JWT.Issuer, "required iss claim")
+	fs.StringVar(&cfg.Grpc.Auth.JWT.Audience, "jwt-audience", cfg.Grpc.Auth.JWT.Audience, "required aud claim")
+	fs.DurationVar(&cfg.Grpc.Auth.JWT.Leeway, "jwt-leeway", cfg.Grpc.Auth.JWT.Leeway, "clock skew tolerated on exp, nbf and iat")
+
+	fs.BoolVar(&cfg.Grpc.RateLimit.Enabled, "rate-limit-enabled", cfg.Grpc.RateLimit.Enabled, "apply token bucket rate limits to RPCs")
+	fs.StringVar(&cfg.Grpc.RateLimit.Key, "rate-limit-key", cfg.Grpc.RateLimit.Key, "rate limit bucket key: identity, peer or api_key")
+	fs.Float64Var(&cfg.Grpc.RateLimit.Default.Rate, "rate-limit-rate", cfg.Grpc.RateLimit.Default.Rate, "calls per second per key for methods without their own rule, 0 means unlimited")
+	fs.IntVar(&cfg.Grpc.RateLimit.Default.Burst, "rate-limit-burst", cfg.Grpc.RateLimit.Default.Burst, "burst size of the default rate limit")
+	fs.Float64Var(&cfg.Grpc.RateLimit.PreAuth.Rate, "rate-limit-pre-auth-rate", cfg.Grpc.RateLimit.PreAuth.Rate, "calls per second per peer checked before authentication, 0 means unlimited")
+	fs.IntVar(&cfg.Grpc.RateLimit.PreAuth.Burst, "rate-limit-pre-auth-burst", cfg.Grpc.RateLimit.PreAuth.Burst, "burst size of the pre-authentication rate limit")
+
+	fs.StringVar(&cfg.Database.DSN, "database-dsn", cfg.Database.DSN, "postgres connection string")
+	fs.StringVar(&cfg.Database.StorageMode, "storage", cfg.Database.StorageMode, "account storage mode: crud or eventsourced")
+	fs.IntVar(&cfg.Database.MaxOpenConns, "database-max-open-conns", cfg.Database.MaxOpenConns, "maximum open database connections, 0 means unlimited")
+	fs.IntVar(&cfg.Database.MaxIdleConns, "database-max-idle-conns", cfg.Database.MaxIdleConns, "maximum idle database connections")
+	fs.DurationVar(&cfg.Database.ConnMaxLifetime, "database-conn-max-lifetime", cfg.Database.ConnMaxLifetime, "maximum lifetime of a database connection, 0 means unlimited")
+
+	fs.StringVar(&cfg.Rates.Provider, "rate-provider", cfg.Rates.Provider, "exchange rate provider: simulator, ecb or csv")
+	fs.StringVar(&cfg.Rates.Source, "rate-source", cfg.Rates.Source, "file path or URL read by the ecb and csv providers")
+	fs.DurationVar(&cfg.Rates.Interval, "rate-interval", cfg.Rates.Interval, "interval between exchange rate imports")
+	fs.StringVar(&cfg.Rates.Simulator.Pairs, "sim-pairs", cfg.Rates.Simulator.Pairs, "simulated pairs as FROM/TO=initial_rate, comma separated")
+	fs.StringVar(&cfg.Rates.Simulator.Model, "sim-model", cfg.Rates.Simulator.Model, "simulator model: random-walk or mean-reverting")
+	fs.Float64Var(&cfg.Rates.Simulator.Volatility, "sim-volatility", cfg.Rates.Simulator.Volatility, "standard deviation of the simulated log return per step")
+	fs.Float64Var(&cfg.Rates.Simulator.MeanReversion, "sim-mean-reversion", cfg.Rates.Simulator.MeanReversion, "fraction of the distance to the initial rate recovered per step")
+	fs.Int64Var(&cfg.Rates.Simulator.Seed, "sim-seed", cfg.Rates.Simulator.Seed, "simulator seed, 0 picks a random seed")
+
+	fs.StringVar(&cfg.Outbox.Sink, "outbox-sink", cfg.Outbox.Sink, "outbox sink: bus, webhook or file")
+	fs.StringVar(&cfg.Outbox.Target, "outbox-target", cfg.Outbox.Target, "webhook url or file path used by the outbox sink")
+	fs.DurationVar(&cfg.Outbox.RelayInterval, "outbox-relay-interval", cfg.Outbox.RelayInterval, "interval between outbox relay runs")
+
+	fs.DurationVar(&cfg.Webhooks.DeliveryInterval, "webhook-delivery-interval", cfg.Webhooks.DeliveryInterval, "interval between webhook delivery runs")
+	fs.DurationVar(&cfg.Webhooks.Timeout, "webhook-timeout", cfg.Webhooks.Timeout, "timeout of a single webhook delivery")
+
+	fs.IntVar(&cfg.Import.BatchSize, "import-batch-size", cfg.Import.BatchSize, "transactions written per database batch by imports")
+
+	fs.DurationVar(&cfg.Health.CheckInterval, "health-check-interval", cfg.Health.CheckInterval, "interval between bank dependency health checks")
+	fs.DurationVar(&cfg.Health.PingTimeout, "health-ping-timeout", cfg.Health.PingTimeout, "timeout of the database health ping")
+	fs.DurationVar(&cfg.Health.MaxRateAge, "health-max-rate-age", cfg.Health.MaxRateAge, "maximum age of the last exchange rate before bank is NOT_SERVING, 0 disables the check")
+
+	fs.BoolVar(&cfg.Admin.Enabled, "admin-enabled", cfg.Admin.Enabled, "serve channelz, pprof, build info and config on the admin address")
+	fs.StringVar(&cfg.Admin.Address, "admin-address", cfg.Admin.Address, "admin listener address, keep it on loopback")
+
+	fs.StringVar(&cfg.Log.Format, "log-format", cfg.Log.Format, "log output format: json or text")
+	fs.StringVar(&cfg.Log.Level, "log-level", cfg.Log.Level, "minimum log level: debug, info, warn or error")
+	fs.Var(stringList{&cfg.Log.PackageLevels}, "log-package-levels", "comma separated per-package levels as package=level")
+
+	return fs
+}
+
+// cada flag pode ser definida pela variável MYGRPC_<NOME_DA_FLAG>
+func applyEnv(fs *flag.FlagSet) error {
+	var err error
+
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || f.Name == configFlag {
+			return
+		}
+
+		env := EnvName(f.Name)
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			return
+		}
+
+		if setErr := fs.Set(f.Name, value); setErr != nil {
+			err = fmt.Errorf("invalid value %q for %v: %w", value, env, setErr)
+		}
+	})
+
+	return err
+}
+
+// flag de lista separada por vírgula. Cada Set substitui a lista inteira
+type stringList struct {
+	values *[]string
+}
+
+func (l stringList) String() string {
+	if l.values == nil {
+		return ""
+	}
+
+	return strings.Join(*l.values, ",")
+}
+
+func (l stringList) Set(value string) error {
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	*l.values = values
+	return nil
+}
+
+func EnvName(flagName string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+// o formato vem da extensão: .yaml/.yml ou .toml. Chaves desconhecidas são rejeitadas
+func loadFile(path string, cfg *Config) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(f)
+		dec.KnownFields(true)
+
+		if err := dec.Decode(cfg); err != nil && err != io.EOF {
+			return fmt.Errorf("failed to parse config file %v: %w", path, err)
+		}
+	case ".toml":
+		md, err := toml.NewDecoder(f).Decode(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to parse config file %v: %w", path, err)
+		}
+
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("failed to parse config file %v: unknown keys %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("unsupported config file format %q, use .yaml, .yml or .toml", filepath.Ext(path))
+	}
+
+	return nil
+}
+
+// serializa a configuração no formato do config print: yaml ou toml
+func Write(w io.Writer, cfg Config, format string) error {
+	switch format {
+	case "yaml", "":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+
+		if err := enc.Encode(cfg); err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+
+		return enc.Close()
+	case "toml":
+		if err := toml.NewEncoder(w).Encode(cfg); err != nil {
+			return fmt.Errorf("failed to encode config: %w", err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown config format %q", format)
+	}
+}
@@ -0,0 +1,147 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/apikey"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/viquitorreis/my-grpc-go-server/internal/port"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const invalidCredentialsMessage = "invalid credentials"
+
+// métodos liberados sem autenticação: nome completo ("/hello.HelloService/SayHello")
+// ou todos os métodos de um serviço ("/grpc.health.v1.Health/*")
+type MethodAllowList []string
+
+func (l MethodAllowList) Allows(fullMethod string) bool {
+	for _, m := range l {
+		if m == fullMethod {
+			return true
+		}
+
+		if service, ok := strings.CutSuffix(m, "*"); ok && strings.HasPrefix(fullMethod, service) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// exige um bearer JWT ou uma chave de API válidos, exceto nos métodos públicos, e coloca
+// o Principal no contexto. verifier ou apiKeys nil desligam o respectivo método
+func AuthUnaryServerInterceptor(verifier port.TokenVerifierPort, apiKeys port.APIKeyAuthenticatorPort,
+	public MethodAllowList) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (resp any, err error) {
+		if public.Allows(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err = authenticate(ctx, verifier, apiKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func AuthStreamServerInterceptor(verifier port.TokenVerifierPort, apiKeys port.APIKeyAuthenticatorPort,
+	public MethodAllowList) grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) error {
+		if public.Allows(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context(), verifier, apiKeys)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// a chave de API tem precedência quando enviada, o JWT é usado caso contrário
+func authenticate(ctx context.Context, verifier port.TokenVerifierPort, apiKeys port.APIKeyAuthenticatorPort) (context.Context, error) {
+	if key, ok := apiKeyFromMetadata(ctx); ok && apiKeys != nil {
+		principal, err := apiKeys.Authenticate(ctx, key)
+		if err != nil {
+			// a mesma mensagem para chave inexistente, revogada, expirada ou segredo errado, senão o client descobre quais key_ids existem
+			logger.WarnContext(ctx, "api key authentication failed", "err", err)
+			return ctx, status.Error(codes.Unauthenticated, invalidCredentialsMessage)
+		}
+
+		return auth.WithPrincipal(ctx, principal), nil
+	}
+
+	token, ok := bearerToken(ctx)
+	if !ok || verifier == nil {
+		return ctx, status.Error(codes.Unauthenticated, missingCredentialsMessage(verifier, apiKeys))
+	}
+
+	principal, err := verifier.Verify(token)
+	if err != nil {
+		// o motivo fica só no log (com o request id do contexto), o client recebe sempre a mesma mensagem
+		logger.WarnContext(ctx, "token verification failed", "err", err)
+		return ctx, status.Error(codes.Unauthenticated, invalidCredentialsMessage)
+	}
+
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+func missingCredentialsMessage(verifier port.TokenVerifierPort, apiKeys port.APIKeyAuthenticatorPort) string {
+	switch {
+	case verifier != nil && apiKeys != nil:
+		return "missing bearer token or api key in metadata"
+	case apiKeys != nil:
+		return "missing api key in " + apikey.MetadataKey + " or authorization metadata"
+	default:
+		return "missing bearer token in authorization metadata"
+	}
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	return authorizationCredentials(ctx, "bearer")
+}
+
+// aceita "x-api-key: <chave>" ou "authorization: ApiKey <chave>"
+func apiKeyFromMetadata(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	for _, v := range md.Get(apikey.MetadataKey) {
+		if key := strings.TrimSpace(v); key != "" {
+			return key, true
+		}
+	}
+
+	return authorizationCredentials(ctx, "apikey")
+}
+
+func authorizationCredentials(ctx context.Context, wantScheme string) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	for _, v := range md.Get("authorization") {
+		scheme, credentials, found := strings.Cut(v, " ")
+		if found && strings.EqualFold(scheme, wantScheme) && strings.TrimSpace(credentials) != "" {
+			return strings.TrimSpace(credentials), true
+		}
+	}
+
+	return "", false
+}
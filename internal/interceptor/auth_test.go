@@ -0,0 +1,169 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// aceita só o token "good", o erro devolvido tem detalhes que não podem chegar ao client
+type fakeVerifier struct{}
+
+func (fakeVerifier) Verify(token string) (auth.Principal, error) {
+	if token != "good" {
+		return auth.Principal{}, errors.New("no verification key for token: alg RS256, kid \"internal-kid\"")
+	}
+
+	return auth.Principal{Subject: "jwt-user", Method: auth.MethodJWT}, nil
+}
+
+func incomingContext(pairs ...string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(pairs...))
+}
+
+// handler que devolve o principal colocado no contexto pelo interceptor
+func principalHandler(ctx context.Context, req any) (any, error) {
+	p, _ := auth.PrincipalFromContext(ctx)
+	return p, nil
+}
+
+func TestMethodAllowList(t *testing.T) {
+	public := MethodAllowList{"/hello.HelloService/SayHello", "/grpc.health.v1.Health/*"}
+
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{"/hello.HelloService/SayHello", true},
+		{"/hello.HelloService/SayManyHellos", false},
+		{"/grpc.health.v1.Health/Check", true},
+		{"/grpc.health.v1.Health/Watch", true},
+		{"/grpc.health.v1.HealthX/Check", false},
+		{"/bank.BankService/GetCurrentBalance", false},
+	}
+
+	for _, tc := range tests {
+		if got := public.Allows(tc.method); got != tc.want {
+			t.Errorf("Allows(%q) = %v, want %v", tc.method, got, tc.want)
+		}
+	}
+}
+
+func TestAuthInterceptorWithJWT(t *testing.T) {
+	intercept := AuthUnaryServerInterceptor(fakeVerifier{}, nil, MethodAllowList{"/hello.HelloService/SayHello"})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		method      string
+		wantCode    codes.Code
+		wantSubject string
+	}{
+		{name: "valid token", ctx: incomingContext("authorization", "Bearer good"),
+			method: "/bank.BankService/GetCurrentBalance", wantCode: codes.OK, wantSubject: "jwt-user"},
+		{name: "scheme is case insensitive", ctx: incomingContext("authorization", "bearer good"),
+			method: "/bank.BankService/GetCurrentBalance", wantCode: codes.OK, wantSubject: "jwt-user"},
+		{name: "invalid token", ctx: incomingContext("authorization", "Bearer bad"),
+			method: "/bank.BankService/GetCurrentBalance", wantCode: codes.Unauthenticated},
+		{name: "missing token", ctx: context.Background(),
+			method: "/bank.BankService/GetCurrentBalance", wantCode: codes.Unauthenticated},
+		{name: "other scheme", ctx: incomingContext("authorization", "Basic Zm9vOmJhcg=="),
+			method: "/bank.BankService/GetCurrentBalance", wantCode: codes.Unauthenticated},
+		{name: "public method without token", ctx: context.Background(),
+			method: "/hello.HelloService/SayHello", wantCode: codes.OK},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := intercept(tc.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method}, principalHandler)
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("got %v, want %v", err, tc.wantCode)
+			}
+
+			if err == nil && resp.(auth.Principal).Subject != tc.wantSubject {
+				t.Errorf("got subject %q, want %q", resp.(auth.Principal).Subject, tc.wantSubject)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptorHidesTokenErrors(t *testing.T) {
+	intercept := AuthUnaryServerInterceptor(fakeVerifier{}, nil, nil)
+	info := &grpc.UnaryServerInfo{FullMethod: "/bank.BankService/GetCurrentBalance"}
+
+	_, err := intercept(incomingContext("authorization", "Bearer bad"), nil, info, principalHandler)
+	if s := status.Convert(err); s.Code() != codes.Unauthenticated || s.Message() != invalidCredentialsMessage {
+		t.Errorf("got %v, want Unauthenticated with %q", err, invalidCredentialsMessage)
+	}
+}
+
+// aceita só a chave "bk_good", o erro devolvido revela se o key_id existe
+type fakeAPIKeys struct{}
+
+func (fakeAPIKeys) Authenticate(ctx context.Context, key string) (auth.Principal, error) {
+	if key != "bk_good" {
+		return auth.Principal{}, errors.New("api key not found")
+	}
+
+	return auth.Principal{Subject: "apikey:batch", Method: auth.MethodAPIKey, CredentialID: "good"}, nil
+}
+
+func TestAuthInterceptorWithAPIKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		verifier    *fakeVerifier
+		ctx         context.Context
+		wantCode    codes.Code
+		wantSubject string
+	}{
+		{name: "x-api-key header", ctx: incomingContext("x-api-key", "bk_good"),
+			wantCode: codes.OK, wantSubject: "apikey:batch"},
+		{name: "authorization apikey scheme", ctx: incomingContext("authorization", "ApiKey bk_good"),
+			wantCode: codes.OK, wantSubject: "apikey:batch"},
+		{name: "api key takes precedence over a valid token", verifier: &fakeVerifier{},
+			ctx:      incomingContext("x-api-key", "bk_good", "authorization", "Bearer good"),
+			wantCode: codes.OK, wantSubject: "apikey:batch"},
+		{name: "invalid api key is not rescued by a valid token", verifier: &fakeVerifier{},
+			ctx:      incomingContext("x-api-key", "bk_bad", "authorization", "Bearer good"),
+			wantCode: codes.Unauthenticated},
+		{name: "token without api key", verifier: &fakeVerifier{},
+			ctx:      incomingContext("authorization", "Bearer good"),
+			wantCode: codes.OK, wantSubject: "jwt-user"},
+		{name: "token with jwt disabled", ctx: incomingContext("authorization", "Bearer good"),
+			wantCode: codes.Unauthenticated},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			intercept := AuthUnaryServerInterceptor(nil, fakeAPIKeys{}, nil)
+			if tc.verifier != nil {
+				intercept = AuthUnaryServerInterceptor(tc.verifier, fakeAPIKeys{}, nil)
+			}
+
+			resp, err := intercept(tc.ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/bank.BankService/GetCurrentBalance"}, principalHandler)
+			if status.Code(err) != tc.wantCode {
+				t.Fatalf("got %v, want %v", err, tc.wantCode)
+			}
+
+			if err == nil && resp.(auth.Principal).Subject != tc.wantSubject {
+				t.Errorf("got subject %q, want %q", resp.(auth.Principal).Subject, tc.wantSubject)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptorHidesAPIKeyErrors(t *testing.T) {
+	intercept := AuthUnaryServerInterceptor(nil, fakeAPIKeys{}, nil)
+	info := &grpc.UnaryServerInfo{FullMethod: "/bank.BankService/GetCurrentBalance"}
+
+	_, err := intercept(incomingContext("x-api-key", "bk_unknown"), nil, info, principalHandler)
+	if s := status.Convert(err); s.Code() != codes.Unauthenticated || s.Message() != invalidCredentialsMessage {
+		t.Errorf("got %v, want Unauthenticated with %q", err, invalidCredentialsMessage)
+	}
+}
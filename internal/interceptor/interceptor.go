@@ -2,19 +2,21 @@ package interceptor
 
 import (
 	"context"
-	"log"
 
+	"github.com/viquitorreis/my-grpc-go-server/internal/logging"
 	"github.com/viquitorreis/my-grpc-proto/protogen/go/hello"
 	"github.com/viquitorreis/my-grpc-proto/protogen/go/resiliency"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
+var logger = logging.Logger("interceptor")
+
 func LogUnaryServerInterceptor() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
 	) (resp any, err error) {
-		log.Println("[LOGGED BY SERVER INTERCEPTOR]", req)
+		logger.DebugContext(ctx, "request intercepted", "method", info.FullMethod, "request", req)
 
 		return handler(ctx, req)
 	}
@@ -63,7 +65,8 @@ func LogStreamServerInterceptor() grpc.StreamServerInterceptor {
 	return func(
 		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
 	) error {
-		log.Println("[LOGGED BY SERVER INTERCEPTOR]", info)
+		logger.DebugContext(ss.Context(), "stream intercepted", "method", info.FullMethod,
+			"client_stream", info.IsClientStream, "server_stream", info.IsServerStream)
 
 		return handler(srv, ss)
 	}
@@ -0,0 +1,233 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/ratelimit"
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type methodLimit struct {
+	limiter    *ratelimit.Limiter
+	perMessage bool
+}
+
+type prefixLimit struct {
+	prefix string
+	limit  *methodLimit
+}
+
+// limites por método, resolvidos pelo nome completo, depois pelos curingas ("/pacote.Servico/*") e por
+// fim pelo default. Os curingas ficam ordenados do prefixo mais longo para o mais curto, então entre
+// regras sobrepostas vale a mais específica
+type RateLimits struct {
+	key      string
+	methods  map[string]*methodLimit
+	prefixes []prefixLimit
+	fallback *methodLimit
+}
+
+func NewRateLimits(cfg config.RateLimitConfig) *RateLimits {
+	l := &RateLimits{
+		key:      cfg.Key,
+		methods:  make(map[string]*methodLimit),
+		fallback: newMethodLimit(cfg.Default),
+	}
+
+	// curinga repetido: a última regra vale, como nos métodos
+	prefixes := make(map[string]*methodLimit)
+
+	for _, r := range cfg.Methods {
+		if prefix, ok := strings.CutSuffix(r.Method, "*"); ok {
+			prefixes[prefix] = newMethodLimit(r)
+			continue
+		}
+
+		l.methods[r.Method] = newMethodLimit(r)
+	}
+
+	for prefix, m := range prefixes {
+		l.prefixes = append(l.prefixes, prefixLimit{prefix: prefix, limit: m})
+	}
+
+	sort.Slice(l.prefixes, func(i, j int) bool {
+		if len(l.prefixes[i].prefix) != len(l.prefixes[j].prefix) {
+			return len(l.prefixes[i].prefix) > len(l.prefixes[j].prefix)
+		}
+
+		return l.prefixes[i].prefix < l.prefixes[j].prefix
+	})
+
+	return l
+}
+
+// regra com rate 0 deixa o método sem limite
+func newMethodLimit(r config.RateLimitRule) *methodLimit {
+	if r.Rate <= 0 {
+		return nil
+	}
+
+	return &methodLimit{
+		limiter:    ratelimit.NewLimiter(r.Rate, r.Burst),
+		perMessage: r.PerMessage,
+	}
+}
+
+func (l *RateLimits) forMethod(fullMethod string) *methodLimit {
+	if m, ok := l.methods[fullMethod]; ok {
+		return m
+	}
+
+	for _, p := range l.prefixes {
+		if strings.HasPrefix(fullMethod, p.prefix) {
+			return p.limit
+		}
+	}
+
+	return l.fallback
+}
+
+// limite por peer para todos os métodos, usado antes da autenticação: o principal ainda não
+// existe e chamadas com credenciais inválidas também consomem o bucket
+func NewPeerRateLimits(rule config.RateLimitRule) *RateLimits {
+	return &RateLimits{
+		key:      config.RateLimitKeyPeer,
+		methods:  make(map[string]*methodLimit),
+		fallback: newMethodLimit(rule),
+	}
+}
+
+// com os limites de NewRateLimits deve vir depois do interceptor de autenticação para o bucket
+// poder ser do principal
+func RateLimitUnaryServerInterceptor(limits *RateLimits) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (resp any, err error) {
+		if m := limits.forMethod(info.FullMethod); m != nil {
+			if err := m.allow(limits.bucketKey(ctx), info.FullMethod); err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// nas regras per_message cada mensagem recebida consome um token e a stream é encerrada
+// com ResourceExhausted quando o cliente passa do limite. Nas demais só a abertura é limitada
+func RateLimitStreamServerInterceptor(limits *RateLimits) grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) error {
+		m := limits.forMethod(info.FullMethod)
+		if m == nil {
+			return handler(srv, ss)
+		}
+
+		key := limits.bucketKey(ss.Context())
+
+		if m.perMessage {
+			return handler(srv, &rateLimitedServerStream{
+				ServerStream: ss,
+				limit:        m,
+				key:          key,
+				method:       info.FullMethod,
+			})
+		}
+
+		if err := m.allow(key, info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+type rateLimitedServerStream struct {
+	grpc.ServerStream
+	limit  *methodLimit
+	key    string
+	method string
+}
+
+func (s *rateLimitedServerStream) RecvMsg(msg any) error {
+	if err := s.ServerStream.RecvMsg(msg); err != nil {
+		return err
+	}
+
+	return s.limit.allow(s.key, s.method)
+}
+
+func (m *methodLimit) allow(key, fullMethod string) error {
+	ok, retryAfter := m.limiter.Allow(key, time.Now())
+	if ok {
+		return nil
+	}
+
+	st := status.Newf(codes.ResourceExhausted, "rate limit exceeded for %v, retry in %v", fullMethod, retryAfter.Round(time.Millisecond))
+	st, _ = st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+
+	return st.Err()
+}
+
+// o método entra na chave pelo limiter, que é um por regra
+func (l *RateLimits) bucketKey(ctx context.Context) string {
+	switch l.key {
+	case config.RateLimitKeyPeer:
+		return "peer:" + peerHost(ctx)
+	case config.RateLimitKeyAPIKey:
+		if p, ok := auth.PrincipalFromContext(ctx); ok && p.Method == auth.MethodAPIKey {
+			return principalBucketKey(p)
+		}
+
+		return "peer:" + peerHost(ctx)
+	default:
+		if p, ok := auth.PrincipalFromContext(ctx); ok {
+			return principalBucketKey(p)
+		}
+
+		if cert, ok := auth.ClientCertificateFromContext(ctx); ok {
+			return "cert:" + cert.Identity()
+		}
+
+		return "peer:" + peerHost(ctx)
+	}
+}
+
+// o método de autenticação prefixa a chave para um JWT com sub "apikey:x" não cair no bucket de uma
+// chave de API. Chaves usam o key_id, único, e não o nome, que pode se repetir entre chaves
+func principalBucketKey(p auth.Principal) string {
+	if p.Method == auth.MethodAPIKey {
+		return auth.MethodAPIKey + ":" + p.CredentialID
+	}
+
+	return p.Method + ":" + p.Subject
+}
+
+// só o host, para as várias conexões do mesmo cliente dividirem o bucket
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
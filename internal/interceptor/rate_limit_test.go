@@ -0,0 +1,108 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/apikey"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func peerContext(ip string) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000}})
+}
+
+func TestBucketKeyDoesNotMixJWTAndAPIKeys(t *testing.T) {
+	for _, key := range []string{config.RateLimitKeyIdentity, config.RateLimitKeyAPIKey} {
+		limits := NewRateLimits(config.RateLimitConfig{Key: key})
+
+		apiKey := apikey.APIKey{KeyID: "k1", Name: "partner"}.Principal()
+		sameName := apikey.APIKey{KeyID: "k2", Name: "partner"}.Principal()
+		jwt := auth.Principal{Subject: apiKey.Subject, Method: auth.MethodJWT}
+
+		keyOf := func(p auth.Principal) string {
+			return limits.bucketKey(auth.WithPrincipal(peerContext("203.0.113.7"), p))
+		}
+
+		if keyOf(apiKey) == keyOf(sameName) {
+			t.Errorf("%v: api keys with the same name share the bucket %q", key, keyOf(apiKey))
+		}
+
+		if keyOf(apiKey) == keyOf(jwt) {
+			t.Errorf("%v: jwt with sub %q shares the api key bucket", key, jwt.Subject)
+		}
+
+		if keyOf(apiKey) != "api_key:k1" {
+			t.Errorf("%v: api key bucket %q, want api_key:k1", key, keyOf(apiKey))
+		}
+	}
+}
+
+func TestPeerRateLimitRunsWithoutPrincipal(t *testing.T) {
+	limits := NewPeerRateLimits(config.RateLimitRule{Rate: 1, Burst: 2})
+	intercept := RateLimitUnaryServerInterceptor(limits)
+	info := &grpc.UnaryServerInfo{FullMethod: "/bank.BankService/GetCurrentBalance"}
+
+	// o handler faz o papel do interceptor de autenticação rejeitando a credencial
+	rejected := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := intercept(peerContext("203.0.113.7"), nil, info, rejected); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("attempt %d: got %v, want Unauthenticated", i, err)
+		}
+	}
+
+	if _, err := intercept(peerContext("203.0.113.7"), nil, info, rejected); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("got %v after the burst, want ResourceExhausted", err)
+	}
+
+	// outro peer tem o próprio bucket
+	if _, err := intercept(peerContext("198.51.100.1"), nil, info, rejected); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("other peer: got %v, want Unauthenticated", err)
+	}
+}
+
+func TestOverlappingWildcardRulesUseTheLongestPrefix(t *testing.T) {
+	// per_message só serve para identificar qual regra foi escolhida
+	bank := config.RateLimitRule{Method: "/bank.*", Rate: 10, Burst: 10}
+	service := config.RateLimitRule{Method: "/bank.BankService/*", Rate: 1, Burst: 1, PerMessage: true}
+	exact := config.RateLimitRule{Method: "/bank.BankService/TransferMultiple", Rate: 0}
+
+	orders := map[string][]config.RateLimitRule{
+		"short first": {bank, service, exact},
+		"long first":  {service, bank, exact},
+	}
+
+	tests := []struct {
+		method     string
+		wantLimit  bool
+		perMessage bool
+	}{
+		{"/bank.BankService/GetCurrentBalance", true, true},
+		{"/bank.BankWebhookService/CreateWebhook", true, false},
+		{"/bank.BankService/TransferMultiple", false, false},
+		{"/hello.HelloService/SayHello", false, false},
+	}
+
+	for name, rules := range orders {
+		// o resultado não pode depender da ordem das regras nem da ordem de iteração, então repete
+		for i := 0; i < 20; i++ {
+			limits := NewRateLimits(config.RateLimitConfig{Key: config.RateLimitKeyPeer, Methods: rules})
+
+			for _, tc := range tests {
+				m := limits.forMethod(tc.method)
+				if (m != nil) != tc.wantLimit || (m != nil && m.perMessage != tc.perMessage) {
+					t.Fatalf("%v: %v matched %+v, want limit %v with per_message %v", name, tc.method, m, tc.wantLimit, tc.perMessage)
+				}
+			}
+		}
+	}
+}
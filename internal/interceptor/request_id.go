@@ -0,0 +1,73 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// usa o x-request-id do client (ou gera um), devolve no header da resposta e registra uma linha
+// por RPC. Deve ser o primeiro da cadeia para os logs dos demais interceptors levarem o id
+func RequestIDUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (resp any, err error) {
+		start := time.Now()
+
+		id := requestID(ctx)
+		ctx = logging.WithRequestID(ctx, id)
+
+		if err := grpc.SetHeader(ctx, metadata.Pairs(logging.RequestIDMetadataKey, id)); err != nil {
+			logger.WarnContext(ctx, "failed to set request id header", "err", err)
+		}
+
+		resp, err = handler(ctx, req)
+		logFinished(ctx, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+func RequestIDStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+
+		id := requestID(ss.Context())
+		ctx := logging.WithRequestID(ss.Context(), id)
+
+		if err := ss.SetHeader(metadata.Pairs(logging.RequestIDMetadataKey, id)); err != nil {
+			logger.WarnContext(ctx, "failed to set request id header", "err", err)
+		}
+
+		err := handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+		logFinished(ctx, info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// ids inválidos do client são trocados para não levar conteúdo arbitrário aos logs
+func requestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(logging.RequestIDMetadataKey); len(values) > 0 && logging.ValidRequestID(values[0]) {
+			return values[0]
+		}
+	}
+
+	return logging.NewRequestID()
+}
+
+func logFinished(ctx context.Context, fullMethod string, start time.Time, err error) {
+	logger.InfoContext(ctx, "rpc finished",
+		"method", fullMethod,
+		"code", status.Code(err).String(),
+		"duration", time.Since(start),
+		"peer", peerHost(ctx),
+	)
+}
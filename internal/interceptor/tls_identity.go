@@ -0,0 +1,67 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// coloca no contexto o certificado do cliente verificado no handshake mTLS, se houver
+func TLSIdentityUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (resp any, err error) {
+		return handler(withClientCertificate(ctx), req)
+	}
+}
+
+func TLSIdentityStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler,
+	) error {
+		return handler(srv, &contextServerStream{
+			ServerStream: ss,
+			ctx:          withClientCertificate(ss.Context()),
+		})
+	}
+}
+
+// stream com o contexto trocado, usado pelos interceptors que enriquecem o contexto
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func withClientCertificate(ctx context.Context) context.Context {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return ctx
+	}
+
+	leaf := tlsInfo.State.VerifiedChains[0][0]
+
+	cert := auth.ClientCertificate{
+		CommonName:     leaf.Subject.CommonName,
+		DNSNames:       leaf.DNSNames,
+		EmailAddresses: leaf.EmailAddresses,
+		SerialNumber:   leaf.SerialNumber.String(),
+	}
+
+	for _, u := range leaf.URIs {
+		cert.URIs = append(cert.URIs, u.String())
+	}
+
+	return auth.WithClientCertificate(ctx, cert)
+}
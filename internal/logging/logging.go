@@ -0,0 +1,160 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/config"
+)
+
+const (
+	FormatJSON string = "json"
+	FormatText string = "text"
+)
+
+const (
+	// atributo com o pacote que gerou o log, usado também para o nível por pacote
+	PackageKey = "package"
+	// atributo com o request id da RPC, adicionado a todo log feito com o contexto da chamada
+	RequestIDKey = "request_id"
+)
+
+// handler e níveis atuais. Os loggers dos pacotes são criados em variáveis globais, antes do Setup,
+// então cada log consulta o estado vigente
+type state struct {
+	handler  slog.Handler
+	level    slog.Level
+	packages map[string]slog.Level
+}
+
+var current atomic.Pointer[state]
+
+func init() {
+	current.Store(&state{
+		handler: slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		level:   slog.LevelInfo,
+	})
+}
+
+// configura o formato e os níveis de todos os loggers e redireciona o pacote log padrão
+// (usado por bibliotecas) para o slog com o pacote "main"
+func Setup(w io.Writer, cfg config.LogConfig) error {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+
+	packages := make(map[string]slog.Level, len(cfg.PackageLevels))
+	for _, entry := range cfg.PackageLevels {
+		pkg, pkgLevel, err := ParsePackageLevel(entry)
+		if err != nil {
+			return err
+		}
+
+		packages[pkg] = pkgLevel
+	}
+
+	// o handler base aceita tudo, o filtro por nível é feito por pacote em Enabled
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+
+	var handler slog.Handler
+	switch cfg.Format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return fmt.Errorf("unsupported log format %q, use %v or %v", cfg.Format, FormatJSON, FormatText)
+	}
+
+	current.Store(&state{
+		handler:  handler,
+		level:    level,
+		packages: packages,
+	})
+
+	slog.SetDefault(Logger("main"))
+	log.SetFlags(0)
+
+	return nil
+}
+
+// logger de um pacote. Deve ser usado com os métodos *Context para levar o request id da RPC
+func Logger(pkg string) *slog.Logger {
+	return slog.New(&packageHandler{pkg: pkg})
+}
+
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return level, fmt.Errorf("invalid log level %q, use debug, info, warn or error", s)
+	}
+
+	return level, nil
+}
+
+// "pacote=nível", ex. "database=debug"
+func ParsePackageLevel(entry string) (string, slog.Level, error) {
+	pkg, levelText, ok := strings.Cut(entry, "=")
+	if !ok || strings.TrimSpace(pkg) == "" {
+		return "", 0, fmt.Errorf("invalid package log level %q, use package=level", entry)
+	}
+
+	level, err := ParseLevel(strings.TrimSpace(levelText))
+	if err != nil {
+		return "", 0, err
+	}
+
+	return strings.TrimSpace(pkg), level, nil
+}
+
+type packageHandler struct {
+	pkg string
+	// WithAttrs e WithGroup são reaplicados sobre o handler vigente a cada log
+	wrap []func(slog.Handler) slog.Handler
+}
+
+func (h *packageHandler) Enabled(_ context.Context, level slog.Level) bool {
+	s := current.Load()
+
+	min, ok := s.packages[h.pkg]
+	if !ok {
+		min = s.level
+	}
+
+	return level >= min
+}
+
+func (h *packageHandler) Handle(ctx context.Context, r slog.Record) error {
+	handler := current.Load().handler.WithAttrs([]slog.Attr{slog.String(PackageKey, h.pkg)})
+	for _, wrap := range h.wrap {
+		handler = wrap(handler)
+	}
+
+	if id, ok := RequestIDFromContext(ctx); ok {
+		r.AddAttrs(slog.String(RequestIDKey, id))
+	}
+
+	return handler.Handle(ctx, r)
+}
+
+func (h *packageHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithAttrs(attrs) })
+}
+
+func (h *packageHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(handler slog.Handler) slog.Handler { return handler.WithGroup(name) })
+}
+
+func (h *packageHandler) with(wrap func(slog.Handler) slog.Handler) slog.Handler {
+	return &packageHandler{
+		pkg:  h.pkg,
+		wrap: append(h.wrap[:len(h.wrap):len(h.wrap)], wrap),
+	}
+}
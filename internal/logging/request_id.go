@@ -0,0 +1,42 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// metadata de entrada com o request id do client e de saída (header) com o id usado pelo servidor
+const RequestIDMetadataKey = "x-request-id"
+
+// ids maiores ou com caracteres fora do ASCII visível são trocados por um gerado
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/apikey"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/auth"
+)
+
+type TokenVerifierPort interface {
+	Verify(token string) (auth.Principal, error)
+}
+
+type APIKeyAuthenticatorPort interface {
+	Authenticate(ctx context.Context, key string) (auth.Principal, error)
+}
+
+type APIKeyServicePort interface {
+	APIKeyAuthenticatorPort
+	CreateAPIKey(ctx context.Context, name string, scopes []string) (apikey.APIKey, string, error)
+	RotateAPIKey(ctx context.Context, apiKeyUUID uuid.UUID, gracePeriod time.Duration) (apikey.APIKey, string, error)
+	RevokeAPIKey(ctx context.Context, apiKeyUUID uuid.UUID) error
+	ListAPIKeys(ctx context.Context) ([]apikey.APIKey, error)
+}
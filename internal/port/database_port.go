@@ -1,24 +1,73 @@
 package port
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/viquitorreis/my-grpc-go-server/internal/adapter/database"
 )
 
+type HealthDatabasePort interface {
+	Ping(ctx context.Context) error
+}
+
 type DummyDatabasePort interface {
 	Save(data *database.DummyOrm) (uuid.UUID, error)
 	GetByUUID(uuid uuid.UUID) (database.DummyOrm, error)
 }
 
 type BankDatabasePort interface {
-	GetBankAccountNumber(account string) (database.BankAccountOrm, error)
-	CreateExchangeRate(r database.BankExchangeRateOrm) (uuid.UUID, error)
-	GetExchangeRate(fromCurrency, toCurrency string, ts time.Time) (database.BankExchangeRateOrm, error)
-	CreateTransaction(account database.BankAccountOrm, t database.BankTransactionOrm) (uuid.UUID, error)
-	CreateTransfer(transfer database.BankTransferOrm) (uuid.UUID, error)
-	CreateTransferTransactionPair(fromAccountOrm database.BankAccountOrm, toAccountOrm database.BankAccountOrm,
-		fromTransactionOrm database.BankTransactionOrm, toTransactionOrm database.BankTransactionOrm) (bool, error)
-	UpdateTransferStatus(transfer database.BankTransferOrm, status bool) error
+	GetBankAccountNumber(ctx context.Context, account string) (database.BankAccountOrm, error)
+	CreateExchangeRate(ctx context.Context, r database.BankExchangeRateOrm) (uuid.UUID, error)
+	GetExchangeRate(ctx context.Context, fromCurrency, toCurrency string, ts time.Time) (database.BankExchangeRateOrm, error)
+	GetExchangeRateHistory(ctx context.Context, fromCurrency, toCurrency string, start, end time.Time,
+		afterTimestamp time.Time, afterUUID uuid.UUID, limit int) ([]database.BankExchangeRateOrm, error)
+	GetExchangeRateCandles(ctx context.Context, fromCurrency, toCurrency, truncUnit string, start, end time.Time,
+		limit int) ([]database.BankExchangeRateCandleOrm, error)
+	GetExchangeSpread(ctx context.Context, fromCurrency, toCurrency, customerTier string) (database.BankExchangeSpreadOrm, error)
+	CreateExchangeQuote(ctx context.Context, q database.BankExchangeQuoteOrm) (uuid.UUID, error)
+	GetExchangeQuote(ctx context.Context, quoteUUID uuid.UUID) (database.BankExchangeQuoteOrm, error)
+	CreateTransaction(ctx context.Context, account database.BankAccountOrm, t database.BankTransactionOrm) (uuid.UUID, error)
+	CreateTransfer(ctx context.Context, transfer database.BankTransferOrm) (uuid.UUID, error)
+	CreateTransferTransactionPair(ctx context.Context, transferOrm database.BankTransferOrm, fromAccountOrm database.BankAccountOrm, toAccountOrm database.BankAccountOrm,
+		fromTransactionOrm database.BankTransactionOrm, toTransactionOrm database.BankTransactionOrm, quoteUUID uuid.UUID) (bool, error)
+	UpdateTransferStatus(ctx context.Context, transfer database.BankTransferOrm, status bool) error
+	MarkTransferFailed(ctx context.Context, transfer database.BankTransferOrm, reason string) error
+	CreateTransferBatch(ctx context.Context, items []database.TransferBatchItemOrm) error
+	CreateTransactionsBulk(ctx context.Context, transactionsOrm []database.BankTransactionOrm) error
+	GetAccountEvents(ctx context.Context, accountUUID uuid.UUID, afterVersion int64, limit int) ([]database.BankAccountEventOrm, error)
+}
+
+type OutboxDatabasePort interface {
+	// executa fn com o lock do relay; devolve false sem executar fn se outra instância estiver publicando
+	WithOutboxRelayLock(ctx context.Context, fn func() error) (bool, error)
+	GetPendingOutboxEvents(limit int) ([]database.BankOutboxOrm, error)
+	MarkOutboxEventPublished(outboxSequence int64) error
+	MarkOutboxEventFailed(outboxSequence int64, lastError string, nextAttemptAt time.Time) error
+	MoveOutboxEventToDeadLetter(event database.BankOutboxOrm, lastError string) error
+}
+
+type WebhookDatabasePort interface {
+	GetBankAccountNumber(ctx context.Context, account string) (database.BankAccountOrm, error)
+	CreateWebhookSubscription(sub database.WebhookSubscriptionOrm) (uuid.UUID, error)
+	DeactivateWebhookSubscription(subscriptionUUID uuid.UUID) error
+	GetActiveWebhookSubscriptions(accountUUIDs []uuid.UUID, eventType string) ([]database.WebhookSubscriptionOrm, error)
+	CreateWebhookDeliveries(deliveries []database.WebhookDeliveryOrm) error
+	GetDueWebhookDeliveries(ts time.Time, limit int) ([]database.WebhookDeliveryOrm, error)
+	RecordWebhookDeliveryAttempt(delivery database.WebhookDeliveryOrm, attempt database.WebhookDeliveryAttemptOrm) error
+	ListWebhookDeliveries(accountUUID uuid.UUID, status string,
+		beforeCreatedAt time.Time, beforeUUID uuid.UUID, limit int) ([]database.WebhookDeliveryOrm, error)
+	GetWebhookDelivery(deliveryUUID uuid.UUID) (database.WebhookDeliveryOrm, error)
+	ResetWebhookDelivery(deliveryUUID uuid.UUID, ts time.Time) error
+}
+
+type APIKeyDatabasePort interface {
+	CreateAPIKey(ctx context.Context, key database.APIKeyOrm) (uuid.UUID, error)
+	GetAPIKey(ctx context.Context, apiKeyUUID uuid.UUID) (database.APIKeyOrm, error)
+	GetAPIKeyByKeyID(ctx context.Context, keyID string) (database.APIKeyOrm, error)
+	ListAPIKeys(ctx context.Context) ([]database.APIKeyOrm, error)
+	RotateAPIKey(ctx context.Context, apiKeyUUID uuid.UUID, keyHash string, previousKeyHash *string, previousExpiresAt *time.Time, ts time.Time) error
+	RevokeAPIKey(ctx context.Context, apiKeyUUID uuid.UUID, ts time.Time) error
+	TouchAPIKey(ctx context.Context, apiKeyUUID uuid.UUID, ts time.Time) error
 }
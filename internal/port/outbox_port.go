@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+type OutboxSinkPort interface {
+	Name() string
+	Publish(ctx context.Context, event bank.OutboxEvent) error
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+type ExchangeRateProviderPort interface {
+	Name() string
+	FetchExchangeRates(ctx context.Context) ([]bank.ExchangeRate, error)
+}
+
+// providers com estado, como o simulador, só avançam depois que o importer confirma as taxas gravadas.
+// As taxas não confirmadas são devolvidas de novo na próxima busca
+type ExchangeRateAckPort interface {
+	AckExchangeRates(rates []bank.ExchangeRate)
+}
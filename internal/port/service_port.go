@@ -1,10 +1,13 @@
 package port
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/health"
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/webhook"
 )
 
 type HelloServicePort interface {
@@ -12,12 +15,53 @@ type HelloServicePort interface {
 }
 
 type BankServicePort interface {
-	FindCurrentBalance(accountId string) (float64, error)
-	CreateExchangeRate(r bank.ExchangeRate) (uuid.UUID, error)
-	GetExchangeRate(fromCurrency, toCurrency string, ts time.Time) (float64, error)
-	CreateTransaction(account string, t bank.Transaction) (uuid.UUID, error)
-	CalculateTransactionSummary(tsum *bank.TransactionSummary, trans bank.Transaction) error
-	Transfer(tt bank.TransferTransaction) (uuid.UUID, bool, error)
+	FindCurrentBalance(ctx context.Context, accountId string) (float64, error)
+	CreateExchangeRate(ctx context.Context, r bank.ExchangeRate) (uuid.UUID, error)
+	GetExchangeRate(ctx context.Context, fromCurrency, toCurrency string, ts time.Time) (float64, error)
+	GetExchangeRatePrice(ctx context.Context, fromCurrency, toCurrency, customerTier string, ts time.Time) (bank.ExchangeRatePrice, error)
+	GetExchangeRateHistory(ctx context.Context, fromCurrency, toCurrency string, start, end time.Time,
+		pageSize int, pageToken string) ([]bank.ExchangeRate, string, error)
+	GetExchangeRateCandles(ctx context.Context, fromCurrency, toCurrency, interval string, start, end time.Time,
+		pageSize int, pageToken string) ([]bank.ExchangeRateCandle, string, error)
+	CreateExchangeQuote(ctx context.Context, fromCurrency, toCurrency, customerTier string) (bank.ExchangeQuote, error)
+	CreateTransaction(ctx context.Context, account string, t bank.Transaction) (uuid.UUID, error)
+	SummarizeTransaction(ctx context.Context, report *bank.TransactionSummaryReport, account string, t bank.Transaction, summarizeOnly bool) error
+	Transfer(ctx context.Context, tt bank.TransferTransaction) (uuid.UUID, bool, error)
+	TransferBatch(ctx context.Context, tts []bank.TransferTransaction) ([]uuid.UUID, error)
+	FindAccountEvents(ctx context.Context, accountNumber string, afterVersion int64, limit int) ([]bank.AccountEvent, error)
+}
+
+type ExchangeRateSubscriptionPort interface {
+	Updates() <-chan bank.ExchangeRatePrice
+	Done() <-chan struct{}
+	Err() error
+	Close()
+}
+
+type ExchangeRateHubPort interface {
+	Subscribe(ctx context.Context, fromCurrency, toCurrency, customerTier string) (ExchangeRateSubscriptionPort, error)
+	PublishExchangeRate(r bank.ExchangeRate)
+	LastPublishedAt() time.Time
+}
+
+type AccountEventHubPort interface {
+	Subscribe(accountNumber string) (<-chan struct{}, func())
+	Notify(accountNumber string)
+}
+
+type TransactionImportServicePort interface {
+	ImportTransactions(ctx context.Context, src TransactionSourcePort, dryRun bool) (bank.TransactionImportResult, error)
+}
+
+type WebhookServicePort interface {
+	CreateSubscription(ctx context.Context, accountNumber, eventType, url string) (webhook.Subscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionUUID uuid.UUID) error
+	ListDeliveries(ctx context.Context, accountNumber, status string, pageSize int, pageToken string) ([]webhook.Delivery, string, error)
+	ReplayDelivery(ctx context.Context, deliveryUUID uuid.UUID) (webhook.Delivery, error)
+}
+
+type HealthServicePort interface {
+	Check(ctx context.Context) health.Report
 }
 
 type ResiliencyServicePort interface {
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/bank"
+)
+
+// fonte de transações para importação em lote. Next devolve io.EOF no fim, um
+// *bank.TransactionImportError para linhas inválidas e qualquer outro erro aborta a importação
+type TransactionSourcePort interface {
+	Next() (bank.ImportedTransaction, error)
+}
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"github.com/viquitorreis/my-grpc-go-server/internal/application/domain/webhook"
+)
+
+type WebhookSenderPort interface {
+	// resolve o host e rejeita destinos fora da internet pública
+	CheckDestination(ctx context.Context, url string) error
+	Send(ctx context.Context, url, secret string, delivery webhook.Delivery) (int, error)
+}
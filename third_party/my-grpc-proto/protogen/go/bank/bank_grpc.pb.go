@@ -0,0 +1,596 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: bank/bank.proto
+
+package bank
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BankService_GetCurrentBalance_FullMethodName         = "/bank.BankService/GetCurrentBalance"
+	BankService_FetchExchangeRates_FullMethodName        = "/bank.BankService/FetchExchangeRates"
+	BankService_SubscribeExchangeRates_FullMethodName    = "/bank.BankService/SubscribeExchangeRates"
+	BankService_CreateExchangeQuote_FullMethodName       = "/bank.BankService/CreateExchangeQuote"
+	BankService_GetExchangeRateHistory_FullMethodName    = "/bank.BankService/GetExchangeRateHistory"
+	BankService_GetExchangeRateCandles_FullMethodName    = "/bank.BankService/GetExchangeRateCandles"
+	BankService_SummarizeTransactions_FullMethodName     = "/bank.BankService/SummarizeTransactions"
+	BankService_ImportTransactions_FullMethodName        = "/bank.BankService/ImportTransactions"
+	BankService_TransferMultiple_FullMethodName          = "/bank.BankService/TransferMultiple"
+	BankService_StreamAccountEvents_FullMethodName       = "/bank.BankService/StreamAccountEvents"
+	BankService_CreateWebhookSubscription_FullMethodName = "/bank.BankService/CreateWebhookSubscription"
+	BankService_DeleteWebhookSubscription_FullMethodName = "/bank.BankService/DeleteWebhookSubscription"
+	BankService_ListWebhookDeliveries_FullMethodName     = "/bank.BankService/ListWebhookDeliveries"
+	BankService_ReplayWebhookDelivery_FullMethodName     = "/bank.BankService/ReplayWebhookDelivery"
+)
+
+// BankServiceClient is the client API for BankService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BankServiceClient interface {
+	GetCurrentBalance(ctx context.Context, in *CurrentBalanceRequest, opts ...grpc.CallOption) (*CurrentBalanceResponse, error)
+	FetchExchangeRates(ctx context.Context, in *ExchangeRateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExchangeRateResponse], error)
+	SubscribeExchangeRates(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExchangeRateSubscriptionRequest, ExchangeRateResponse], error)
+	CreateExchangeQuote(ctx context.Context, in *ExchangeQuoteRequest, opts ...grpc.CallOption) (*ExchangeQuoteResponse, error)
+	GetExchangeRateHistory(ctx context.Context, in *ExchangeRateHistoryRequest, opts ...grpc.CallOption) (*ExchangeRateHistoryResponse, error)
+	GetExchangeRateCandles(ctx context.Context, in *ExchangeRateCandlesRequest, opts ...grpc.CallOption) (*ExchangeRateCandlesResponse, error)
+	SummarizeTransactions(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Transaction, TransactionSummary], error)
+	ImportTransactions(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTransactionsRequest, ImportTransactionsResponse], error)
+	TransferMultiple(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TransferRequest, TransferResponse], error)
+	StreamAccountEvents(ctx context.Context, in *AccountEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AccountEvent], error)
+	CreateWebhookSubscription(ctx context.Context, in *CreateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, in *DeleteWebhookSubscriptionRequest, opts ...grpc.CallOption) (*DeleteWebhookSubscriptionResponse, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
+}
+
+type bankServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBankServiceClient(cc grpc.ClientConnInterface) BankServiceClient {
+	return &bankServiceClient{cc}
+}
+
+func (c *bankServiceClient) GetCurrentBalance(ctx context.Context, in *CurrentBalanceRequest, opts ...grpc.CallOption) (*CurrentBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CurrentBalanceResponse)
+	err := c.cc.Invoke(ctx, BankService_GetCurrentBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) FetchExchangeRates(ctx context.Context, in *ExchangeRateRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExchangeRateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BankService_ServiceDesc.Streams[0], BankService_FetchExchangeRates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExchangeRateRequest, ExchangeRateResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_FetchExchangeRatesClient = grpc.ServerStreamingClient[ExchangeRateResponse]
+
+func (c *bankServiceClient) SubscribeExchangeRates(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExchangeRateSubscriptionRequest, ExchangeRateResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BankService_ServiceDesc.Streams[1], BankService_SubscribeExchangeRates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExchangeRateSubscriptionRequest, ExchangeRateResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_SubscribeExchangeRatesClient = grpc.BidiStreamingClient[ExchangeRateSubscriptionRequest, ExchangeRateResponse]
+
+func (c *bankServiceClient) CreateExchangeQuote(ctx context.Context, in *ExchangeQuoteRequest, opts ...grpc.CallOption) (*ExchangeQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExchangeQuoteResponse)
+	err := c.cc.Invoke(ctx, BankService_CreateExchangeQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) GetExchangeRateHistory(ctx context.Context, in *ExchangeRateHistoryRequest, opts ...grpc.CallOption) (*ExchangeRateHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExchangeRateHistoryResponse)
+	err := c.cc.Invoke(ctx, BankService_GetExchangeRateHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) GetExchangeRateCandles(ctx context.Context, in *ExchangeRateCandlesRequest, opts ...grpc.CallOption) (*ExchangeRateCandlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExchangeRateCandlesResponse)
+	err := c.cc.Invoke(ctx, BankService_GetExchangeRateCandles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) SummarizeTransactions(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[Transaction, TransactionSummary], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BankService_ServiceDesc.Streams[2], BankService_SummarizeTransactions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[Transaction, TransactionSummary]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_SummarizeTransactionsClient = grpc.ClientStreamingClient[Transaction, TransactionSummary]
+
+func (c *bankServiceClient) ImportTransactions(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportTransactionsRequest, ImportTransactionsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BankService_ServiceDesc.Streams[3], BankService_ImportTransactions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportTransactionsRequest, ImportTransactionsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_ImportTransactionsClient = grpc.ClientStreamingClient[ImportTransactionsRequest, ImportTransactionsResponse]
+
+func (c *bankServiceClient) TransferMultiple(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[TransferRequest, TransferResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BankService_ServiceDesc.Streams[4], BankService_TransferMultiple_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TransferRequest, TransferResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_TransferMultipleClient = grpc.BidiStreamingClient[TransferRequest, TransferResponse]
+
+func (c *bankServiceClient) StreamAccountEvents(ctx context.Context, in *AccountEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AccountEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BankService_ServiceDesc.Streams[5], BankService_StreamAccountEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AccountEventsRequest, AccountEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_StreamAccountEventsClient = grpc.ServerStreamingClient[AccountEvent]
+
+func (c *bankServiceClient) CreateWebhookSubscription(ctx context.Context, in *CreateWebhookSubscriptionRequest, opts ...grpc.CallOption) (*WebhookSubscription, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookSubscription)
+	err := c.cc.Invoke(ctx, BankService_CreateWebhookSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) DeleteWebhookSubscription(ctx context.Context, in *DeleteWebhookSubscriptionRequest, opts ...grpc.CallOption) (*DeleteWebhookSubscriptionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteWebhookSubscriptionResponse)
+	err := c.cc.Invoke(ctx, BankService_DeleteWebhookSubscription_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, BankService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bankServiceClient) ReplayWebhookDelivery(ctx context.Context, in *ReplayWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, BankService_ReplayWebhookDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BankServiceServer is the server API for BankService service.
+// All implementations must embed UnimplementedBankServiceServer
+// for forward compatibility.
+type BankServiceServer interface {
+	GetCurrentBalance(context.Context, *CurrentBalanceRequest) (*CurrentBalanceResponse, error)
+	FetchExchangeRates(*ExchangeRateRequest, grpc.ServerStreamingServer[ExchangeRateResponse]) error
+	SubscribeExchangeRates(grpc.BidiStreamingServer[ExchangeRateSubscriptionRequest, ExchangeRateResponse]) error
+	CreateExchangeQuote(context.Context, *ExchangeQuoteRequest) (*ExchangeQuoteResponse, error)
+	GetExchangeRateHistory(context.Context, *ExchangeRateHistoryRequest) (*ExchangeRateHistoryResponse, error)
+	GetExchangeRateCandles(context.Context, *ExchangeRateCandlesRequest) (*ExchangeRateCandlesResponse, error)
+	SummarizeTransactions(grpc.ClientStreamingServer[Transaction, TransactionSummary]) error
+	ImportTransactions(grpc.ClientStreamingServer[ImportTransactionsRequest, ImportTransactionsResponse]) error
+	TransferMultiple(grpc.BidiStreamingServer[TransferRequest, TransferResponse]) error
+	StreamAccountEvents(*AccountEventsRequest, grpc.ServerStreamingServer[AccountEvent]) error
+	CreateWebhookSubscription(context.Context, *CreateWebhookSubscriptionRequest) (*WebhookSubscription, error)
+	DeleteWebhookSubscription(context.Context, *DeleteWebhookSubscriptionRequest) (*DeleteWebhookSubscriptionResponse, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*WebhookDelivery, error)
+	mustEmbedUnimplementedBankServiceServer()
+}
+
+// UnimplementedBankServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBankServiceServer struct{}
+
+func (UnimplementedBankServiceServer) GetCurrentBalance(context.Context, *CurrentBalanceRequest) (*CurrentBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCurrentBalance not implemented")
+}
+func (UnimplementedBankServiceServer) FetchExchangeRates(*ExchangeRateRequest, grpc.ServerStreamingServer[ExchangeRateResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method FetchExchangeRates not implemented")
+}
+func (UnimplementedBankServiceServer) SubscribeExchangeRates(grpc.BidiStreamingServer[ExchangeRateSubscriptionRequest, ExchangeRateResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeExchangeRates not implemented")
+}
+func (UnimplementedBankServiceServer) CreateExchangeQuote(context.Context, *ExchangeQuoteRequest) (*ExchangeQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExchangeQuote not implemented")
+}
+func (UnimplementedBankServiceServer) GetExchangeRateHistory(context.Context, *ExchangeRateHistoryRequest) (*ExchangeRateHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchangeRateHistory not implemented")
+}
+func (UnimplementedBankServiceServer) GetExchangeRateCandles(context.Context, *ExchangeRateCandlesRequest) (*ExchangeRateCandlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchangeRateCandles not implemented")
+}
+func (UnimplementedBankServiceServer) SummarizeTransactions(grpc.ClientStreamingServer[Transaction, TransactionSummary]) error {
+	return status.Errorf(codes.Unimplemented, "method SummarizeTransactions not implemented")
+}
+func (UnimplementedBankServiceServer) ImportTransactions(grpc.ClientStreamingServer[ImportTransactionsRequest, ImportTransactionsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportTransactions not implemented")
+}
+func (UnimplementedBankServiceServer) TransferMultiple(grpc.BidiStreamingServer[TransferRequest, TransferResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method TransferMultiple not implemented")
+}
+func (UnimplementedBankServiceServer) StreamAccountEvents(*AccountEventsRequest, grpc.ServerStreamingServer[AccountEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamAccountEvents not implemented")
+}
+func (UnimplementedBankServiceServer) CreateWebhookSubscription(context.Context, *CreateWebhookSubscriptionRequest) (*WebhookSubscription, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhookSubscription not implemented")
+}
+func (UnimplementedBankServiceServer) DeleteWebhookSubscription(context.Context, *DeleteWebhookSubscriptionRequest) (*DeleteWebhookSubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhookSubscription not implemented")
+}
+func (UnimplementedBankServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedBankServiceServer) ReplayWebhookDelivery(context.Context, *ReplayWebhookDeliveryRequest) (*WebhookDelivery, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayWebhookDelivery not implemented")
+}
+func (UnimplementedBankServiceServer) mustEmbedUnimplementedBankServiceServer() {}
+func (UnimplementedBankServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeBankServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BankServiceServer will
+// result in compilation errors.
+type UnsafeBankServiceServer interface {
+	mustEmbedUnimplementedBankServiceServer()
+}
+
+func RegisterBankServiceServer(s grpc.ServiceRegistrar, srv BankServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBankServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BankService_ServiceDesc, srv)
+}
+
+func _BankService_GetCurrentBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CurrentBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).GetCurrentBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_GetCurrentBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).GetCurrentBalance(ctx, req.(*CurrentBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_FetchExchangeRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExchangeRateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BankServiceServer).FetchExchangeRates(m, &grpc.GenericServerStream[ExchangeRateRequest, ExchangeRateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_FetchExchangeRatesServer = grpc.ServerStreamingServer[ExchangeRateResponse]
+
+func _BankService_SubscribeExchangeRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BankServiceServer).SubscribeExchangeRates(&grpc.GenericServerStream[ExchangeRateSubscriptionRequest, ExchangeRateResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_SubscribeExchangeRatesServer = grpc.BidiStreamingServer[ExchangeRateSubscriptionRequest, ExchangeRateResponse]
+
+func _BankService_CreateExchangeQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).CreateExchangeQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_CreateExchangeQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).CreateExchangeQuote(ctx, req.(*ExchangeQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_GetExchangeRateHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeRateHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).GetExchangeRateHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_GetExchangeRateHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).GetExchangeRateHistory(ctx, req.(*ExchangeRateHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_GetExchangeRateCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeRateCandlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).GetExchangeRateCandles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_GetExchangeRateCandles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).GetExchangeRateCandles(ctx, req.(*ExchangeRateCandlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_SummarizeTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BankServiceServer).SummarizeTransactions(&grpc.GenericServerStream[Transaction, TransactionSummary]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_SummarizeTransactionsServer = grpc.ClientStreamingServer[Transaction, TransactionSummary]
+
+func _BankService_ImportTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BankServiceServer).ImportTransactions(&grpc.GenericServerStream[ImportTransactionsRequest, ImportTransactionsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_ImportTransactionsServer = grpc.ClientStreamingServer[ImportTransactionsRequest, ImportTransactionsResponse]
+
+func _BankService_TransferMultiple_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BankServiceServer).TransferMultiple(&grpc.GenericServerStream[TransferRequest, TransferResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_TransferMultipleServer = grpc.BidiStreamingServer[TransferRequest, TransferResponse]
+
+func _BankService_StreamAccountEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AccountEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BankServiceServer).StreamAccountEvents(m, &grpc.GenericServerStream[AccountEventsRequest, AccountEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BankService_StreamAccountEventsServer = grpc.ServerStreamingServer[AccountEvent]
+
+func _BankService_CreateWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).CreateWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_CreateWebhookSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).CreateWebhookSubscription(ctx, req.(*CreateWebhookSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_DeleteWebhookSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).DeleteWebhookSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_DeleteWebhookSubscription_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).DeleteWebhookSubscription(ctx, req.(*DeleteWebhookSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BankService_ReplayWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BankServiceServer).ReplayWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BankService_ReplayWebhookDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BankServiceServer).ReplayWebhookDelivery(ctx, req.(*ReplayWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BankService_ServiceDesc is the grpc.ServiceDesc for BankService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BankService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bank.BankService",
+	HandlerType: (*BankServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCurrentBalance",
+			Handler:    _BankService_GetCurrentBalance_Handler,
+		},
+		{
+			MethodName: "CreateExchangeQuote",
+			Handler:    _BankService_CreateExchangeQuote_Handler,
+		},
+		{
+			MethodName: "GetExchangeRateHistory",
+			Handler:    _BankService_GetExchangeRateHistory_Handler,
+		},
+		{
+			MethodName: "GetExchangeRateCandles",
+			Handler:    _BankService_GetExchangeRateCandles_Handler,
+		},
+		{
+			MethodName: "CreateWebhookSubscription",
+			Handler:    _BankService_CreateWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "DeleteWebhookSubscription",
+			Handler:    _BankService_DeleteWebhookSubscription_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _BankService_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ReplayWebhookDelivery",
+			Handler:    _BankService_ReplayWebhookDelivery_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FetchExchangeRates",
+			Handler:       _BankService_FetchExchangeRates_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeExchangeRates",
+			Handler:       _BankService_SubscribeExchangeRates_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SummarizeTransactions",
+			Handler:       _BankService_SummarizeTransactions_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ImportTransactions",
+			Handler:       _BankService_ImportTransactions_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "TransferMultiple",
+			Handler:       _BankService_TransferMultiple_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamAccountEvents",
+			Handler:       _BankService_StreamAccountEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "bank/bank.proto",
+}
@@ -0,0 +1,267 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.3
+// 	protoc        (unknown)
+// source: resiliency/resiliency.proto
+
+package resiliency
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ResiliencyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MinDelaySecond int32                  `protobuf:"varint,1,opt,name=min_delay_second,json=minDelaySecond,proto3" json:"min_delay_second,omitempty"`
+	MaxDelaySecond int32                  `protobuf:"varint,2,opt,name=max_delay_second,json=maxDelaySecond,proto3" json:"max_delay_second,omitempty"`
+	StatusCodes    []uint32               `protobuf:"varint,3,rep,packed,name=status_codes,json=statusCodes,proto3" json:"status_codes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResiliencyRequest) Reset() {
+	*x = ResiliencyRequest{}
+	mi := &file_resiliency_resiliency_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResiliencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResiliencyRequest) ProtoMessage() {}
+
+func (x *ResiliencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_resiliency_resiliency_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResiliencyRequest.ProtoReflect.Descriptor instead.
+func (*ResiliencyRequest) Descriptor() ([]byte, []int) {
+	return file_resiliency_resiliency_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ResiliencyRequest) GetMinDelaySecond() int32 {
+	if x != nil {
+		return x.MinDelaySecond
+	}
+	return 0
+}
+
+func (x *ResiliencyRequest) GetMaxDelaySecond() int32 {
+	if x != nil {
+		return x.MaxDelaySecond
+	}
+	return 0
+}
+
+func (x *ResiliencyRequest) GetStatusCodes() []uint32 {
+	if x != nil {
+		return x.StatusCodes
+	}
+	return nil
+}
+
+type ResiliencyReponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DummyString   string                 `protobuf:"bytes,1,opt,name=dummy_string,json=dummyString,proto3" json:"dummy_string,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResiliencyReponse) Reset() {
+	*x = ResiliencyReponse{}
+	mi := &file_resiliency_resiliency_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResiliencyReponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResiliencyReponse) ProtoMessage() {}
+
+func (x *ResiliencyReponse) ProtoReflect() protoreflect.Message {
+	mi := &file_resiliency_resiliency_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResiliencyReponse.ProtoReflect.Descriptor instead.
+func (*ResiliencyReponse) Descriptor() ([]byte, []int) {
+	return file_resiliency_resiliency_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResiliencyReponse) GetDummyString() string {
+	if x != nil {
+		return x.DummyString
+	}
+	return ""
+}
+
+var File_resiliency_resiliency_proto protoreflect.FileDescriptor
+
+var file_resiliency_resiliency_proto_rawDesc = []byte{
+	0x0a, 0x1b, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x2f, 0x72, 0x65, 0x73,
+	0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x72,
+	0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x8a, 0x01, 0x0a, 0x11, 0x52, 0x65,
+	0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x28, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x44, 0x65,
+	0x6c, 0x61, 0x79, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78,
+	0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x63, 0x6f,
+	0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x43, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x36, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69,
+	0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x64,
+	0x75, 0x6d, 0x6d, 0x79, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x75, 0x6d, 0x6d, 0x79, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x32, 0xfb,
+	0x02, 0x0a, 0x11, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x0f, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69,
+	0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65,
+	0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x16, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x12,
+	0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73,
+	0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x69,
+	0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x58, 0x0a, 0x16, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69,
+	0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69, 0x6c,
+	0x69, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79,
+	0x52, 0x65, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x61, 0x0a, 0x1d, 0x42, 0x69, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x73,
+	0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e,
+	0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69,
+	0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63,
+	0x79, 0x52, 0x65, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x32, 0xb7, 0x03, 0x0a,
+	0x1d, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x57, 0x69, 0x74, 0x68, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5b,
+	0x0a, 0x1b, 0x55, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63,
+	0x79, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x2e,
+	0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c,
+	0x69, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72,
+	0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69,
+	0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x22, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x69, 0x6c,
+	0x69, 0x65, 0x6e, 0x63, 0x79, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52,
+	0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65,
+	0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30,
+	0x01, 0x12, 0x64, 0x0a, 0x22, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x57, 0x69, 0x74, 0x68, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69,
+	0x65, 0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65,
+	0x6e, 0x63, 0x79, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x6d, 0x0a, 0x29, 0x42, 0x69, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
+	0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63,
+	0x79, 0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79,
+	0x2e, 0x52, 0x65, 0x73, 0x69, 0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x42, 0x3e, 0x5a, 0x3c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x76, 0x69, 0x71, 0x75, 0x69, 0x74, 0x6f, 0x72, 0x72, 0x65, 0x69,
+	0x73, 0x2f, 0x6d, 0x79, 0x2d, 0x67, 0x72, 0x70, 0x63, 0x2d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x72, 0x65, 0x73, 0x69,
+	0x6c, 0x69, 0x65, 0x6e, 0x63, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_resiliency_resiliency_proto_rawDescOnce sync.Once
+	file_resiliency_resiliency_proto_rawDescData = file_resiliency_resiliency_proto_rawDesc
+)
+
+func file_resiliency_resiliency_proto_rawDescGZIP() []byte {
+	file_resiliency_resiliency_proto_rawDescOnce.Do(func() {
+		file_resiliency_resiliency_proto_rawDescData = protoimpl.X.CompressGZIP(file_resiliency_resiliency_proto_rawDescData)
+	})
+	return file_resiliency_resiliency_proto_rawDescData
+}
+
+var file_resiliency_resiliency_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_resiliency_resiliency_proto_goTypes = []any{
+	(*ResiliencyRequest)(nil), // 0: resiliency.ResiliencyRequest
+	(*ResiliencyReponse)(nil), // 1: resiliency.ResiliencyReponse
+}
+var file_resiliency_resiliency_proto_depIdxs = []int32{
+	0, // 0: resiliency.ResiliencyService.UnaryResiliency:input_type -> resiliency.ResiliencyRequest
+	0, // 1: resiliency.ResiliencyService.ServerStreamResiliency:input_type -> resiliency.ResiliencyRequest
+	0, // 2: resiliency.ResiliencyService.ClientStreamResiliency:input_type -> resiliency.ResiliencyRequest
+	0, // 3: resiliency.ResiliencyService.BidirectionalStreamResiliency:input_type -> resiliency.ResiliencyRequest
+	0, // 4: resiliency.ResiliencyWithMetadataService.UnaryResiliencyWithMetadata:input_type -> resiliency.ResiliencyRequest
+	0, // 5: resiliency.ResiliencyWithMetadataService.ServerStreamResiliencyWithMetadata:input_type -> resiliency.ResiliencyRequest
+	0, // 6: resiliency.ResiliencyWithMetadataService.ClientStreamResiliencyWithMetadata:input_type -> resiliency.ResiliencyRequest
+	0, // 7: resiliency.ResiliencyWithMetadataService.BidirectionalStreamResiliencyWithMetadata:input_type -> resiliency.ResiliencyRequest
+	1, // 8: resiliency.ResiliencyService.UnaryResiliency:output_type -> resiliency.ResiliencyReponse
+	1, // 9: resiliency.ResiliencyService.ServerStreamResiliency:output_type -> resiliency.ResiliencyReponse
+	1, // 10: resiliency.ResiliencyService.ClientStreamResiliency:output_type -> resiliency.ResiliencyReponse
+	1, // 11: resiliency.ResiliencyService.BidirectionalStreamResiliency:output_type -> resiliency.ResiliencyReponse
+	1, // 12: resiliency.ResiliencyWithMetadataService.UnaryResiliencyWithMetadata:output_type -> resiliency.ResiliencyReponse
+	1, // 13: resiliency.ResiliencyWithMetadataService.ServerStreamResiliencyWithMetadata:output_type -> resiliency.ResiliencyReponse
+	1, // 14: resiliency.ResiliencyWithMetadataService.ClientStreamResiliencyWithMetadata:output_type -> resiliency.ResiliencyReponse
+	1, // 15: resiliency.ResiliencyWithMetadataService.BidirectionalStreamResiliencyWithMetadata:output_type -> resiliency.ResiliencyReponse
+	8, // [8:16] is the sub-list for method output_type
+	0, // [0:8] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_resiliency_resiliency_proto_init() }
+func file_resiliency_resiliency_proto_init() {
+	if File_resiliency_resiliency_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_resiliency_resiliency_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_resiliency_resiliency_proto_goTypes,
+		DependencyIndexes: file_resiliency_resiliency_proto_depIdxs,
+		MessageInfos:      file_resiliency_resiliency_proto_msgTypes,
+	}.Build()
+	File_resiliency_resiliency_proto = out.File
+	file_resiliency_resiliency_proto_rawDesc = nil
+	file_resiliency_resiliency_proto_goTypes = nil
+	file_resiliency_resiliency_proto_depIdxs = nil
+}
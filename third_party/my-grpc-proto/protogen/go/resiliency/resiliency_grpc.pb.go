@@ -0,0 +1,434 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: resiliency/resiliency.proto
+
+package resiliency
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ResiliencyService_UnaryResiliency_FullMethodName               = "/resiliency.ResiliencyService/UnaryResiliency"
+	ResiliencyService_ServerStreamResiliency_FullMethodName        = "/resiliency.ResiliencyService/ServerStreamResiliency"
+	ResiliencyService_ClientStreamResiliency_FullMethodName        = "/resiliency.ResiliencyService/ClientStreamResiliency"
+	ResiliencyService_BidirectionalStreamResiliency_FullMethodName = "/resiliency.ResiliencyService/BidirectionalStreamResiliency"
+)
+
+// ResiliencyServiceClient is the client API for ResiliencyService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ResiliencyServiceClient interface {
+	UnaryResiliency(ctx context.Context, in *ResiliencyRequest, opts ...grpc.CallOption) (*ResiliencyReponse, error)
+	ServerStreamResiliency(ctx context.Context, in *ResiliencyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResiliencyReponse], error)
+	ClientStreamResiliency(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ResiliencyRequest, ResiliencyReponse], error)
+	BidirectionalStreamResiliency(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ResiliencyRequest, ResiliencyReponse], error)
+}
+
+type resiliencyServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResiliencyServiceClient(cc grpc.ClientConnInterface) ResiliencyServiceClient {
+	return &resiliencyServiceClient{cc}
+}
+
+func (c *resiliencyServiceClient) UnaryResiliency(ctx context.Context, in *ResiliencyRequest, opts ...grpc.CallOption) (*ResiliencyReponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResiliencyReponse)
+	err := c.cc.Invoke(ctx, ResiliencyService_UnaryResiliency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resiliencyServiceClient) ServerStreamResiliency(ctx context.Context, in *ResiliencyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResiliencyReponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ResiliencyService_ServiceDesc.Streams[0], ResiliencyService_ServerStreamResiliency_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ResiliencyRequest, ResiliencyReponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyService_ServerStreamResiliencyClient = grpc.ServerStreamingClient[ResiliencyReponse]
+
+func (c *resiliencyServiceClient) ClientStreamResiliency(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ResiliencyRequest, ResiliencyReponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ResiliencyService_ServiceDesc.Streams[1], ResiliencyService_ClientStreamResiliency_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ResiliencyRequest, ResiliencyReponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyService_ClientStreamResiliencyClient = grpc.ClientStreamingClient[ResiliencyRequest, ResiliencyReponse]
+
+func (c *resiliencyServiceClient) BidirectionalStreamResiliency(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ResiliencyRequest, ResiliencyReponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ResiliencyService_ServiceDesc.Streams[2], ResiliencyService_BidirectionalStreamResiliency_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ResiliencyRequest, ResiliencyReponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyService_BidirectionalStreamResiliencyClient = grpc.BidiStreamingClient[ResiliencyRequest, ResiliencyReponse]
+
+// ResiliencyServiceServer is the server API for ResiliencyService service.
+// All implementations must embed UnimplementedResiliencyServiceServer
+// for forward compatibility.
+type ResiliencyServiceServer interface {
+	UnaryResiliency(context.Context, *ResiliencyRequest) (*ResiliencyReponse, error)
+	ServerStreamResiliency(*ResiliencyRequest, grpc.ServerStreamingServer[ResiliencyReponse]) error
+	ClientStreamResiliency(grpc.ClientStreamingServer[ResiliencyRequest, ResiliencyReponse]) error
+	BidirectionalStreamResiliency(grpc.BidiStreamingServer[ResiliencyRequest, ResiliencyReponse]) error
+	mustEmbedUnimplementedResiliencyServiceServer()
+}
+
+// UnimplementedResiliencyServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedResiliencyServiceServer struct{}
+
+func (UnimplementedResiliencyServiceServer) UnaryResiliency(context.Context, *ResiliencyRequest) (*ResiliencyReponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnaryResiliency not implemented")
+}
+func (UnimplementedResiliencyServiceServer) ServerStreamResiliency(*ResiliencyRequest, grpc.ServerStreamingServer[ResiliencyReponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ServerStreamResiliency not implemented")
+}
+func (UnimplementedResiliencyServiceServer) ClientStreamResiliency(grpc.ClientStreamingServer[ResiliencyRequest, ResiliencyReponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ClientStreamResiliency not implemented")
+}
+func (UnimplementedResiliencyServiceServer) BidirectionalStreamResiliency(grpc.BidiStreamingServer[ResiliencyRequest, ResiliencyReponse]) error {
+	return status.Errorf(codes.Unimplemented, "method BidirectionalStreamResiliency not implemented")
+}
+func (UnimplementedResiliencyServiceServer) mustEmbedUnimplementedResiliencyServiceServer() {}
+func (UnimplementedResiliencyServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeResiliencyServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ResiliencyServiceServer will
+// result in compilation errors.
+type UnsafeResiliencyServiceServer interface {
+	mustEmbedUnimplementedResiliencyServiceServer()
+}
+
+func RegisterResiliencyServiceServer(s grpc.ServiceRegistrar, srv ResiliencyServiceServer) {
+	// If the following call pancis, it indicates UnimplementedResiliencyServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ResiliencyService_ServiceDesc, srv)
+}
+
+func _ResiliencyService_UnaryResiliency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResiliencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResiliencyServiceServer).UnaryResiliency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResiliencyService_UnaryResiliency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResiliencyServiceServer).UnaryResiliency(ctx, req.(*ResiliencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResiliencyService_ServerStreamResiliency_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ResiliencyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResiliencyServiceServer).ServerStreamResiliency(m, &grpc.GenericServerStream[ResiliencyRequest, ResiliencyReponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyService_ServerStreamResiliencyServer = grpc.ServerStreamingServer[ResiliencyReponse]
+
+func _ResiliencyService_ClientStreamResiliency_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ResiliencyServiceServer).ClientStreamResiliency(&grpc.GenericServerStream[ResiliencyRequest, ResiliencyReponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyService_ClientStreamResiliencyServer = grpc.ClientStreamingServer[ResiliencyRequest, ResiliencyReponse]
+
+func _ResiliencyService_BidirectionalStreamResiliency_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ResiliencyServiceServer).BidirectionalStreamResiliency(&grpc.GenericServerStream[ResiliencyRequest, ResiliencyReponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyService_BidirectionalStreamResiliencyServer = grpc.BidiStreamingServer[ResiliencyRequest, ResiliencyReponse]
+
+// ResiliencyService_ServiceDesc is the grpc.ServiceDesc for ResiliencyService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ResiliencyService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "resiliency.ResiliencyService",
+	HandlerType: (*ResiliencyServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UnaryResiliency",
+			Handler:    _ResiliencyService_UnaryResiliency_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ServerStreamResiliency",
+			Handler:       _ResiliencyService_ServerStreamResiliency_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ClientStreamResiliency",
+			Handler:       _ResiliencyService_ClientStreamResiliency_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "BidirectionalStreamResiliency",
+			Handler:       _ResiliencyService_BidirectionalStreamResiliency_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "resiliency/resiliency.proto",
+}
+
+const (
+	ResiliencyWithMetadataService_UnaryResiliencyWithMetadata_FullMethodName               = "/resiliency.ResiliencyWithMetadataService/UnaryResiliencyWithMetadata"
+	ResiliencyWithMetadataService_ServerStreamResiliencyWithMetadata_FullMethodName        = "/resiliency.ResiliencyWithMetadataService/ServerStreamResiliencyWithMetadata"
+	ResiliencyWithMetadataService_ClientStreamResiliencyWithMetadata_FullMethodName        = "/resiliency.ResiliencyWithMetadataService/ClientStreamResiliencyWithMetadata"
+	ResiliencyWithMetadataService_BidirectionalStreamResiliencyWithMetadata_FullMethodName = "/resiliency.ResiliencyWithMetadataService/BidirectionalStreamResiliencyWithMetadata"
+)
+
+// ResiliencyWithMetadataServiceClient is the client API for ResiliencyWithMetadataService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ResiliencyWithMetadataServiceClient interface {
+	UnaryResiliencyWithMetadata(ctx context.Context, in *ResiliencyRequest, opts ...grpc.CallOption) (*ResiliencyReponse, error)
+	ServerStreamResiliencyWithMetadata(ctx context.Context, in *ResiliencyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResiliencyReponse], error)
+	ClientStreamResiliencyWithMetadata(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ResiliencyRequest, ResiliencyReponse], error)
+	BidirectionalStreamResiliencyWithMetadata(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ResiliencyRequest, ResiliencyReponse], error)
+}
+
+type resiliencyWithMetadataServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResiliencyWithMetadataServiceClient(cc grpc.ClientConnInterface) ResiliencyWithMetadataServiceClient {
+	return &resiliencyWithMetadataServiceClient{cc}
+}
+
+func (c *resiliencyWithMetadataServiceClient) UnaryResiliencyWithMetadata(ctx context.Context, in *ResiliencyRequest, opts ...grpc.CallOption) (*ResiliencyReponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResiliencyReponse)
+	err := c.cc.Invoke(ctx, ResiliencyWithMetadataService_UnaryResiliencyWithMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resiliencyWithMetadataServiceClient) ServerStreamResiliencyWithMetadata(ctx context.Context, in *ResiliencyRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ResiliencyReponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ResiliencyWithMetadataService_ServiceDesc.Streams[0], ResiliencyWithMetadataService_ServerStreamResiliencyWithMetadata_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ResiliencyRequest, ResiliencyReponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyWithMetadataService_ServerStreamResiliencyWithMetadataClient = grpc.ServerStreamingClient[ResiliencyReponse]
+
+func (c *resiliencyWithMetadataServiceClient) ClientStreamResiliencyWithMetadata(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ResiliencyRequest, ResiliencyReponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ResiliencyWithMetadataService_ServiceDesc.Streams[1], ResiliencyWithMetadataService_ClientStreamResiliencyWithMetadata_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ResiliencyRequest, ResiliencyReponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyWithMetadataService_ClientStreamResiliencyWithMetadataClient = grpc.ClientStreamingClient[ResiliencyRequest, ResiliencyReponse]
+
+func (c *resiliencyWithMetadataServiceClient) BidirectionalStreamResiliencyWithMetadata(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ResiliencyRequest, ResiliencyReponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ResiliencyWithMetadataService_ServiceDesc.Streams[2], ResiliencyWithMetadataService_BidirectionalStreamResiliencyWithMetadata_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ResiliencyRequest, ResiliencyReponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyWithMetadataService_BidirectionalStreamResiliencyWithMetadataClient = grpc.BidiStreamingClient[ResiliencyRequest, ResiliencyReponse]
+
+// ResiliencyWithMetadataServiceServer is the server API for ResiliencyWithMetadataService service.
+// All implementations must embed UnimplementedResiliencyWithMetadataServiceServer
+// for forward compatibility.
+type ResiliencyWithMetadataServiceServer interface {
+	UnaryResiliencyWithMetadata(context.Context, *ResiliencyRequest) (*ResiliencyReponse, error)
+	ServerStreamResiliencyWithMetadata(*ResiliencyRequest, grpc.ServerStreamingServer[ResiliencyReponse]) error
+	ClientStreamResiliencyWithMetadata(grpc.ClientStreamingServer[ResiliencyRequest, ResiliencyReponse]) error
+	BidirectionalStreamResiliencyWithMetadata(grpc.BidiStreamingServer[ResiliencyRequest, ResiliencyReponse]) error
+	mustEmbedUnimplementedResiliencyWithMetadataServiceServer()
+}
+
+// UnimplementedResiliencyWithMetadataServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedResiliencyWithMetadataServiceServer struct{}
+
+func (UnimplementedResiliencyWithMetadataServiceServer) UnaryResiliencyWithMetadata(context.Context, *ResiliencyRequest) (*ResiliencyReponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnaryResiliencyWithMetadata not implemented")
+}
+func (UnimplementedResiliencyWithMetadataServiceServer) ServerStreamResiliencyWithMetadata(*ResiliencyRequest, grpc.ServerStreamingServer[ResiliencyReponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ServerStreamResiliencyWithMetadata not implemented")
+}
+func (UnimplementedResiliencyWithMetadataServiceServer) ClientStreamResiliencyWithMetadata(grpc.ClientStreamingServer[ResiliencyRequest, ResiliencyReponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ClientStreamResiliencyWithMetadata not implemented")
+}
+func (UnimplementedResiliencyWithMetadataServiceServer) BidirectionalStreamResiliencyWithMetadata(grpc.BidiStreamingServer[ResiliencyRequest, ResiliencyReponse]) error {
+	return status.Errorf(codes.Unimplemented, "method BidirectionalStreamResiliencyWithMetadata not implemented")
+}
+func (UnimplementedResiliencyWithMetadataServiceServer) mustEmbedUnimplementedResiliencyWithMetadataServiceServer() {
+}
+func (UnimplementedResiliencyWithMetadataServiceServer) testEmbeddedByValue() {}
+
+// UnsafeResiliencyWithMetadataServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ResiliencyWithMetadataServiceServer will
+// result in compilation errors.
+type UnsafeResiliencyWithMetadataServiceServer interface {
+	mustEmbedUnimplementedResiliencyWithMetadataServiceServer()
+}
+
+func RegisterResiliencyWithMetadataServiceServer(s grpc.ServiceRegistrar, srv ResiliencyWithMetadataServiceServer) {
+	// If the following call pancis, it indicates UnimplementedResiliencyWithMetadataServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ResiliencyWithMetadataService_ServiceDesc, srv)
+}
+
+func _ResiliencyWithMetadataService_UnaryResiliencyWithMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResiliencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResiliencyWithMetadataServiceServer).UnaryResiliencyWithMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResiliencyWithMetadataService_UnaryResiliencyWithMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResiliencyWithMetadataServiceServer).UnaryResiliencyWithMetadata(ctx, req.(*ResiliencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResiliencyWithMetadataService_ServerStreamResiliencyWithMetadata_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ResiliencyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ResiliencyWithMetadataServiceServer).ServerStreamResiliencyWithMetadata(m, &grpc.GenericServerStream[ResiliencyRequest, ResiliencyReponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyWithMetadataService_ServerStreamResiliencyWithMetadataServer = grpc.ServerStreamingServer[ResiliencyReponse]
+
+func _ResiliencyWithMetadataService_ClientStreamResiliencyWithMetadata_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ResiliencyWithMetadataServiceServer).ClientStreamResiliencyWithMetadata(&grpc.GenericServerStream[ResiliencyRequest, ResiliencyReponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyWithMetadataService_ClientStreamResiliencyWithMetadataServer = grpc.ClientStreamingServer[ResiliencyRequest, ResiliencyReponse]
+
+func _ResiliencyWithMetadataService_BidirectionalStreamResiliencyWithMetadata_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ResiliencyWithMetadataServiceServer).BidirectionalStreamResiliencyWithMetadata(&grpc.GenericServerStream[ResiliencyRequest, ResiliencyReponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ResiliencyWithMetadataService_BidirectionalStreamResiliencyWithMetadataServer = grpc.BidiStreamingServer[ResiliencyRequest, ResiliencyReponse]
+
+// ResiliencyWithMetadataService_ServiceDesc is the grpc.ServiceDesc for ResiliencyWithMetadataService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ResiliencyWithMetadataService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "resiliency.ResiliencyWithMetadataService",
+	HandlerType: (*ResiliencyWithMetadataServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UnaryResiliencyWithMetadata",
+			Handler:    _ResiliencyWithMetadataService_UnaryResiliencyWithMetadata_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ServerStreamResiliencyWithMetadata",
+			Handler:       _ResiliencyWithMetadataService_ServerStreamResiliencyWithMetadata_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ClientStreamResiliencyWithMetadata",
+			Handler:       _ResiliencyWithMetadataService_ClientStreamResiliencyWithMetadata_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "BidirectionalStreamResiliencyWithMetadata",
+			Handler:       _ResiliencyWithMetadataService_BidirectionalStreamResiliencyWithMetadata_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "resiliency/resiliency.proto",
+}